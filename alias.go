@@ -0,0 +1,52 @@
+package valtruc
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// RegisterAlias lets you use name in tags as a shortcut for rules.
+// Errors coming from those rules keep their own identifier and report
+// the alias name in GetAlias.
+func (vt *Valtruc) RegisterAlias(name string, rules string) {
+	parseValtrucTag(rules, reflect.StructField{}, nil)
+	vt.aliases[name] = rules
+}
+
+func (vt Valtruc) expandAliases(tags []valTag, expanding []string) []valTag {
+	result := make([]valTag, 0, len(tags))
+	for _, tag := range tags {
+		rules, ok := vt.aliases[tag.name]
+		if !ok || tag.negated || len(tag.alternatives) > 0 || len(tag.params) > 0 {
+			result = append(result, tag)
+			continue
+		}
+		if slices.Contains(expanding, tag.name) {
+			panic(fmt.Sprintf("valtruc: alias %s is recursive", tag.name))
+		}
+
+		expanded := vt.expandAliases(
+			parseValtrucTag(rules, tag.field, tag.structType),
+			append(expanding, tag.name))
+		for _, e := range expanded {
+			e.alias = tag.name
+			if len(e.groups) == 0 {
+				e.groups = tag.groups
+			}
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+func aliasValidatorWrapper(inner Validator, alias string) Validator {
+	return func(ctx ValidationContext) (bool, error) {
+		ok, err := inner(ctx)
+		if verr, isValidationError := err.(ValidationError); !ok && isValidationError {
+			verr.alias = alias
+			return false, verr
+		}
+		return ok, err
+	}
+}
@@ -0,0 +1,143 @@
+package valtruc
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type RuleSet interface {
+	ruleSet() (reflect.Type, map[string][]string)
+}
+
+type StructRules[T any] struct {
+	structType reflect.Type
+	fields     map[string][]string
+}
+
+type FieldRules[T any] struct {
+	parent *StructRules[T]
+	field  reflect.StructField
+}
+
+func For[T any]() *StructRules[T] {
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		panic("valtruc.For only accepts structs!")
+	}
+	return &StructRules[T]{
+		structType: t,
+		fields:     map[string][]string{},
+	}
+}
+
+func (sr *StructRules[T]) ruleSet() (reflect.Type, map[string][]string) {
+	return sr.structType, sr.fields
+}
+
+// Field selects the field returned by selector. Unlike the Field method,
+// a selector with a wrong signature does not compile.
+func Field[T, F any](sr *StructRules[T], selector func(*T) *F) *FieldRules[T] {
+	return sr.Field(selector)
+}
+
+// Field selects the field returned by selector, which must be a function
+// like func(u *User) *string { return &u.Name }. Its signature is checked
+// when Field runs; use the Field function to check it when compiling.
+func (sr *StructRules[T]) Field(selector any) *FieldRules[T] {
+	return &FieldRules[T]{
+		parent: sr,
+		field:  selectField(sr.structType, selector),
+	}
+}
+
+func selectField(t reflect.Type, selector any) reflect.StructField {
+	sv := reflect.ValueOf(selector)
+	st := sv.Type()
+	if st.Kind() != reflect.Func ||
+		st.NumIn() != 1 || st.In(0) != reflect.PointerTo(t) ||
+		st.NumOut() != 1 || st.Out(0).Kind() != reflect.Ptr {
+		panic(fmt.Sprintf("valtruc: field selector must be a func(*%s) *FieldType", t.Name()))
+	}
+
+	target := reflect.New(t)
+	selected := sv.Call([]reflect.Value{target})[0]
+	offset := selected.Pointer() - target.Pointer()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if field.Offset == offset && field.Type == selected.Type().Elem() {
+			return field
+		}
+	}
+	panic(fmt.Sprintf("valtruc: field selector must return a pointer to a field of %s", t.Name()))
+}
+
+func (fr *FieldRules[T]) ruleSet() (reflect.Type, map[string][]string) {
+	return fr.parent.ruleSet()
+}
+
+func (fr *FieldRules[T]) Field(selector any) *FieldRules[T] {
+	return fr.parent.Field(selector)
+}
+
+func (fr *FieldRules[T]) Rule(name string, params ...string) *FieldRules[T] {
+	rule := name
+	if len(params) > 0 {
+		escaped := make([]string, len(params))
+		for i, param := range params {
+			escaped[i] = escapeParam(param)
+		}
+		rule = fmt.Sprintf("%s=%s", name, strings.Join(escaped, string(paramSeparator)))
+	}
+	fr.parent.fields[fr.field.Name] = append(fr.parent.fields[fr.field.Name], rule)
+	return fr
+}
+
+func (fr *FieldRules[T]) Required() *FieldRules[T] {
+	return fr.Rule("required")
+}
+
+func (fr *FieldRules[T]) Min(value any) *FieldRules[T] {
+	return fr.Rule("min", fmt.Sprint(value))
+}
+
+func (fr *FieldRules[T]) Max(value any) *FieldRules[T] {
+	return fr.Rule("max", fmt.Sprint(value))
+}
+
+func (fr *FieldRules[T]) Contains(substr string) *FieldRules[T] {
+	return fr.Rule("contains", substr)
+}
+
+func (fr *FieldRules[T]) Between(minv, maxv any) *FieldRules[T] {
+	return fr.Rule("between", fmt.Sprint(minv), fmt.Sprint(maxv))
+}
+
+func (fr *FieldRules[T]) OneOf(values ...any) *FieldRules[T] {
+	params := make([]string, len(values))
+	for i, value := range values {
+		params[i] = fmt.Sprint(value)
+	}
+	return fr.Rule("oneof", params...)
+}
+
+func (fr *FieldRules[T]) MustBeTrue() *FieldRules[T] {
+	return fr.Rule("mustBeTrue")
+}
+
+func (fr *FieldRules[T]) MustBeFalse() *FieldRules[T] {
+	return fr.Rule("mustBeFalse")
+}
+
+func (vt Valtruc) AddRules(rules RuleSet) {
+	t, fields := rules.ruleSet()
+	e, ok := vt.rules[t]
+	if !ok {
+		e = map[string]string{}
+		vt.rules[t] = e
+	}
+	for field, tags := range fields {
+		e[field] = strings.Join(tags, ",")
+	}
+	vt.compileStructValidation(t)
+}
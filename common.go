@@ -1,9 +1,15 @@
 package valtruc
 
-import "reflect"
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
 
 const (
-	RequiredIdentifier ValidatorIdentifier = "requiredIdentifier"
+	RequiredIdentifier      ValidatorIdentifier = "requiredIdentifier"
+	EqualFieldIdentifier    ValidatorIdentifier = "equalFieldIdentifier"
+	NotEqualFieldIdentifier ValidatorIdentifier = "notEqualFieldIdentifier"
 )
 
 func require(_ string) Validator {
@@ -19,3 +25,85 @@ func require(_ string) Validator {
 		return true, nil
 	}
 }
+
+type zeroer interface {
+	IsZero() bool
+}
+
+func isEmpty(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	case reflect.Struct:
+		if !value.CanInterface() {
+			break
+		}
+		if z, ok := value.Interface().(zeroer); ok {
+			return z.IsZero()
+		}
+	}
+	return value.IsZero()
+}
+
+// checkOtherField panics when a field validator names a field that is not
+// in the struct, so typos fail when the tag is compiled.
+func checkOtherField(tag valTag) {
+	if tag.name != "eqfield" && tag.name != "nefield" {
+		return
+	}
+	name := strings.Join(tag.params, string(paramSeparator))
+	if len(name) == 0 {
+		name = varOtherFieldName
+	}
+	if _, ok := tag.structType.FieldByName(name); !ok {
+		panic(fmt.Sprintf("valtruc: field %s not found in struct %s", name, tag.structType.Name()))
+	}
+}
+
+func otherField(ctx ValidationContext, name string) (reflect.Value, bool) {
+	other := ctx.StructValue.FieldByName(name)
+	if !other.IsValid() {
+		panic(fmt.Sprintf("valtruc: field %s not found in struct %s", name, ctx.StructType.Name()))
+	}
+	if other.Kind() == reflect.Ptr {
+		if other.IsNil() {
+			return other, false
+		}
+		other = other.Elem()
+	}
+	return other, true
+}
+
+func equalField(param string) Validator {
+	if len(param) == 0 {
+		param = varOtherFieldName
+	}
+	return func(ctx ValidationContext) (bool, error) {
+		other, ok := otherField(ctx, param)
+		if !ok || !reflect.DeepEqual(ctx.FieldValue.Interface(), other.Interface()) {
+			return false, NewValidationErrorMeta(
+				ctx,
+				fmt.Sprintf("the field must be equal to field %s", param),
+				EqualFieldIdentifier,
+				param)
+		}
+		return true, nil
+	}
+}
+
+func notEqualField(param string) Validator {
+	if len(param) == 0 {
+		param = varOtherFieldName
+	}
+	return func(ctx ValidationContext) (bool, error) {
+		other, ok := otherField(ctx, param)
+		if ok && reflect.DeepEqual(ctx.FieldValue.Interface(), other.Interface()) {
+			return false, NewValidationErrorMeta(
+				ctx,
+				fmt.Sprintf("the field must not be equal to field %s", param),
+				NotEqualFieldIdentifier,
+				param)
+		}
+		return true, nil
+	}
+}
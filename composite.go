@@ -0,0 +1,53 @@
+package valtruc
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	AnyOfIdentifier ValidatorIdentifier = "anyOfIdentifier"
+	NotIdentifier   ValidatorIdentifier = "notIdentifier"
+)
+
+func anyOf(alternatives []Validator, tag valTag) Validator {
+	return func(ctx ValidationContext) (bool, error) {
+		causes := []error{}
+		for _, alternative := range alternatives {
+			ok, err := alternative(ctx)
+			if ok {
+				return true, nil
+			}
+			causes = append(causes, err)
+		}
+
+		reasons := make([]string, len(causes))
+		for i, cause := range causes {
+			reasons[i] = cause.Error()
+			if verr, ok := cause.(ValidationError); ok {
+				reasons[i] = fmt.Sprintf("[%s] %s", verr.GetIdentifier(), verr.msg)
+			}
+		}
+		verr := NewValidationErrorMeta(
+			ctx,
+			fmt.Sprintf("the field must satisfy one of %s: %s", tag.original, strings.Join(reasons, "; ")),
+			AnyOfIdentifier,
+			tag.original)
+		verr.causes = causes
+		return false, verr
+	}
+}
+
+func not(inner Validator, tag valTag) Validator {
+	return func(ctx ValidationContext) (bool, error) {
+		ok, _ := inner(ctx)
+		if ok {
+			return false, NewValidationErrorMeta(
+				ctx,
+				fmt.Sprintf("the field must not satisfy %s", tag.original),
+				NotIdentifier,
+				tag.original)
+		}
+		return true, nil
+	}
+}
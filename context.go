@@ -0,0 +1,53 @@
+package valtruc
+
+import (
+	"context"
+	"reflect"
+)
+
+// ValidatorCtx is a validator that needs a context, like the ones doing
+// I/O. It receives the context given to ValidateCtx, or
+// context.Background when validating with Validate.
+type ValidatorCtx func(ctx context.Context, vctx ValidationContext) (bool, error)
+type CtxValidatorConstructor func(param string) ValidatorCtx
+
+func (vt *Valtruc) AddCtxValidator(forKind reflect.Kind, tagName string, constructor CtxValidatorConstructor) {
+	vt.AddValidator(forKind, tagName, func(param string) Validator {
+		return withContext(constructor(param))
+	})
+	names, ok := vt.ctxNames[forKind]
+	if !ok {
+		names = map[string]bool{}
+		vt.ctxNames[forKind] = names
+	}
+	names[tagName] = true
+}
+
+func (vt Valtruc) usesContext(tag valTag, kind reflect.Kind) bool {
+	for _, alternative := range tag.alternatives {
+		if vt.usesContext(alternative, kind) {
+			return true
+		}
+	}
+	return vt.ctxNames[kind][tag.name]
+}
+
+func withContext(inner ValidatorCtx) Validator {
+	return func(vctx ValidationContext) (bool, error) {
+		return inner(vctx.Context, vctx)
+	}
+}
+
+// ValidateCtx validates target like Validate, passing ctx to context aware
+// validators. If ctx is done before finishing, it stops and returns ctx.Err().
+func (vt Valtruc) ValidateCtx(ctx context.Context, target interface{}) ([]error, error) {
+	t := reflect.TypeOf(target)
+	v := reflect.ValueOf(target)
+	run := vt.newRun()
+	run.ctx = ctx
+	errs := vt.validate(t, v, vt.compiledFor(t), run)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return errs, nil
+}
@@ -0,0 +1,170 @@
+package valtruc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	Base64Identifier    ValidatorIdentifier = "base64Identifier"
+	Base64URLIdentifier ValidatorIdentifier = "base64URLIdentifier"
+	HexIdentifier       ValidatorIdentifier = "hexIdentifier"
+	JSONIdentifier      ValidatorIdentifier = "jsonIdentifier"
+	UTF8Identifier      ValidatorIdentifier = "utf8Identifier"
+	JWTIdentifier       ValidatorIdentifier = "jwtIdentifier"
+)
+
+// OffsetMetadata is the metadata key with the offset of the first invalid
+// byte in errors of encoding validators.
+const OffsetMetadata = "offset"
+
+// bytesOf gives the content of a string or a []byte.
+func bytesOf(value reflect.Value) []byte {
+	if value.Kind() == reflect.String {
+		return []byte(value.String())
+	}
+	return value.Bytes()
+}
+
+func isByteSlice(t reflect.Type) bool {
+	return t.Elem().Kind() == reflect.Uint8
+}
+
+// encodingValidator builds a validator from a check that gives the offset
+// of the first invalid byte, or -1 when the data is valid.
+func encodingValidator(check func([]byte) int, format string, identifier ValidatorIdentifier) ValidatorConstructor {
+	return func(_ string) Validator {
+		return func(ctx ValidationContext) (bool, error) {
+			offset := check(bytesOf(ctx.FieldValue))
+			if offset != -1 {
+				return false, NewValidationError(
+					ctx,
+					fmt.Sprintf("the field must be valid %s, invalid data at offset %d", format, offset),
+					identifier).WithMetadata(OffsetMetadata, offset)
+			}
+			return true, nil
+		}
+	}
+}
+
+func base64Offset(encoding *base64.Encoding, data []byte) int {
+	_, err := encoding.Decode(make([]byte, encoding.DecodedLen(len(data))), data)
+	if corrupt := base64.CorruptInputError(0); errors.As(err, &corrupt) {
+		return int(corrupt)
+	}
+	return -1
+}
+
+func checkBase64(data []byte) int {
+	return base64Offset(base64.StdEncoding, data)
+}
+
+// checkBase64URL accepts the URL alphabet with or without padding.
+func checkBase64URL(data []byte) int {
+	if bytes.IndexByte(data, '=') == -1 {
+		return base64Offset(base64.RawURLEncoding, data)
+	}
+	return base64Offset(base64.URLEncoding, data)
+}
+
+func checkHex(data []byte) int {
+	for i, c := range data {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", rune(c)) {
+			return i
+		}
+	}
+	if len(data)%2 != 0 {
+		return len(data)
+	}
+	return -1
+}
+
+// checkJSON decodes a single value. Syntax errors are reported after
+// reading the invalid byte, and truncated input ends in io.EOF.
+func checkJSON(data []byte) int {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	var value any
+	err := decoder.Decode(&value)
+	var syntax *json.SyntaxError
+	switch {
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return len(data)
+	case errors.As(err, &syntax):
+		return int(syntax.Offset) - 1
+	case err != nil:
+		return 0
+	}
+	for i := int(decoder.InputOffset()); i < len(data); i++ {
+		if !strings.ContainsRune(" \t\r\n", rune(data[i])) {
+			return i
+		}
+	}
+	return -1
+}
+
+func checkUTF8(data []byte) int {
+	for i := 0; i < len(data); {
+		c, size := utf8.DecodeRune(data[i:])
+		if c == utf8.RuneError && size <= 1 {
+			return i
+		}
+		i += size
+	}
+	return -1
+}
+
+// checkJWT checks the structure of a JSON Web Token: a header and a payload
+// with JSON objects and a signature, encoded in base64url and separated by
+// dots. The signature is not verified.
+func checkJWT(data []byte) int {
+	parts := bytes.Split(data, []byte("."))
+	if len(parts) != 3 {
+		return len(data)
+	}
+	start := 0
+	for i, part := range parts {
+		if offset := base64Offset(base64.RawURLEncoding, part); offset != -1 {
+			return start + offset
+		}
+		if i < 2 {
+			decoded, _ := base64.RawURLEncoding.DecodeString(string(part))
+			var object map[string]any
+			if json.Unmarshal(decoded, &object) != nil || object == nil {
+				return start
+			}
+		}
+		start += len(part) + 1
+	}
+	return -1
+}
+
+func base64Validator(param string) Validator {
+	return encodingValidator(checkBase64, "base64", Base64Identifier)(param)
+}
+
+func base64URLValidator(param string) Validator {
+	return encodingValidator(checkBase64URL, "base64url", Base64URLIdentifier)(param)
+}
+
+func hexValidator(param string) Validator {
+	return encodingValidator(checkHex, "hexadecimal", HexIdentifier)(param)
+}
+
+func jsonValidator(param string) Validator {
+	return encodingValidator(checkJSON, "JSON", JSONIdentifier)(param)
+}
+
+func utf8Validator(param string) Validator {
+	return encodingValidator(checkUTF8, "UTF-8", UTF8Identifier)(param)
+}
+
+func jwtValidator(param string) Validator {
+	return encodingValidator(checkJWT, "JWT", JWTIdentifier)(param)
+}
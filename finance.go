@@ -0,0 +1,240 @@
+package valtruc
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	CreditCardIdentifier ValidatorIdentifier = "creditCardIdentifier"
+	IBANIdentifier       ValidatorIdentifier = "ibanIdentifier"
+	BICIdentifier        ValidatorIdentifier = "bicIdentifier"
+	ISINIdentifier       ValidatorIdentifier = "isinIdentifier"
+)
+
+// Metadata keys set by the financial validators.
+const (
+	BrandMetadata   = "brand"
+	CountryMetadata = "country"
+)
+
+type cardBrand struct {
+	name     string
+	prefixes [][2]int
+	lengths  []int
+}
+
+// cardBrands are checked in order, so the more specific prefixes go first.
+var cardBrands = []cardBrand{
+	{name: "amex", prefixes: [][2]int{{34, 34}, {37, 37}}, lengths: []int{15}},
+	{name: "diners", prefixes: [][2]int{{300, 305}, {36, 36}, {38, 39}}, lengths: []int{14, 15, 16, 17, 18, 19}},
+	{name: "jcb", prefixes: [][2]int{{3528, 3589}}, lengths: []int{16, 17, 18, 19}},
+	{name: "visa", prefixes: [][2]int{{4, 4}}, lengths: []int{13, 16, 19}},
+	{name: "mastercard", prefixes: [][2]int{{51, 55}, {2221, 2720}}, lengths: []int{16}},
+	{name: "discover", prefixes: [][2]int{{6011, 6011}, {644, 649}, {65, 65}}, lengths: []int{16, 17, 18, 19}},
+	{name: "unionpay", prefixes: [][2]int{{62, 62}}, lengths: []int{16, 17, 18, 19}},
+	{name: "maestro", prefixes: [][2]int{{50, 50}, {56, 69}}, lengths: []int{12, 13, 14, 15, 16, 17, 18, 19}},
+}
+
+func (brand cardBrand) matches(number string) bool {
+	for _, prefix := range brand.prefixes {
+		digits := len(strconv.Itoa(prefix[0]))
+		if len(number) < digits {
+			continue
+		}
+		start, _ := strconv.Atoi(number[:digits])
+		if start >= prefix[0] && start <= prefix[1] {
+			return true
+		}
+	}
+	return false
+}
+
+func detectCardBrand(number string) (cardBrand, bool) {
+	for _, brand := range cardBrands {
+		if brand.matches(number) {
+			return brand, true
+		}
+	}
+	return cardBrand{}, false
+}
+
+// luhn checks the digit at the end of a number, as used in card numbers.
+func luhn(number string) bool {
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		digit := int(number[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+func isDigits(str string) bool {
+	for _, c := range str {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return len(str) > 0
+}
+
+func isUpperAlnum(str string) bool {
+	for _, c := range str {
+		if (c < '0' || c > '9') && (c < 'A' || c > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+func isUpperAlpha(str string) bool {
+	for _, c := range str {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// creditCard checks card numbers, that can be grouped with spaces or dashes.
+func creditCard(_ string) Validator {
+	return func(ctx ValidationContext) (bool, error) {
+		number := strings.NewReplacer(" ", "", "-", "").Replace(ctx.FieldValue.String())
+		if !isDigits(number) {
+			return false, NewValidationError(
+				ctx,
+				"the field must be a credit card number",
+				CreditCardIdentifier)
+		}
+		brand, ok := detectCardBrand(number)
+		if !ok {
+			return false, NewValidationError(
+				ctx,
+				"the field must be a credit card number of a known brand",
+				CreditCardIdentifier)
+		}
+		if !slices.Contains(brand.lengths, len(number)) || !luhn(number) {
+			return false, NewValidationError(
+				ctx,
+				fmt.Sprintf("the field must be a valid %s card number", brand.name),
+				CreditCardIdentifier).WithMetadata(BrandMetadata, brand.name)
+		}
+		return true, nil
+	}
+}
+
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16,
+	"BG": 22, "BH": 22, "BI": 27, "BR": 29, "BY": 28, "CH": 21, "CR": 22,
+	"CY": 28, "CZ": 24, "DE": 22, "DJ": 27, "DK": 18, "DO": 28, "EE": 20,
+	"EG": 29, "ES": 24, "FI": 18, "FK": 18, "FO": 18, "FR": 27, "GB": 22,
+	"GE": 22, "GI": 23, "GL": 18, "GR": 27, "GT": 28, "HR": 21, "HU": 28,
+	"IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27, "JO": 30, "KW": 30,
+	"KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20, "LV": 21,
+	"LY": 25, "MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20, "MR": 27,
+	"MT": 31, "MU": 30, "NI": 28, "NL": 18, "NO": 15, "OM": 23, "PK": 24,
+	"PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "RU": 33,
+	"SA": 24, "SC": 31, "SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27,
+	"SO": 23, "ST": 25, "SV": 28, "TL": 23, "TN": 24, "TR": 26, "UA": 29,
+	"VA": 22, "VG": 24, "XK": 20, "YE": 30,
+}
+
+// mod97 gives the remainder of the number made by replacing each letter
+// of str with two digits (A is 10, B is 11...).
+func mod97(str string) int {
+	remainder := 0
+	for _, c := range str {
+		if c >= 'A' {
+			remainder = (remainder*100 + int(c-'A') + 10) % 97
+		} else {
+			remainder = (remainder*10 + int(c-'0')) % 97
+		}
+	}
+	return remainder
+}
+
+// iban checks an account number in electronic (no spaces) or print
+// (groups of four) format.
+func iban(_ string) Validator {
+	return func(ctx ValidationContext) (bool, error) {
+		account := strings.ReplaceAll(ctx.FieldValue.String(), " ", "")
+		if len(account) < 4 || !isUpperAlnum(account) || !isUpperAlpha(account[:2]) {
+			return false, NewValidationError(
+				ctx,
+				"the field must be an IBAN",
+				IBANIdentifier)
+		}
+		country := account[:2]
+		length, ok := ibanLengths[country]
+		if !ok {
+			return false, NewValidationError(
+				ctx,
+				fmt.Sprintf("the field must be an IBAN, %s does not use IBAN", country),
+				IBANIdentifier).WithMetadata(CountryMetadata, country)
+		}
+		if len(account) != length || mod97(account[4:]+account[:4]) != 1 {
+			return false, NewValidationError(
+				ctx,
+				fmt.Sprintf("the field must be a valid IBAN for %s", country),
+				IBANIdentifier).WithMetadata(CountryMetadata, country)
+		}
+		return true, nil
+	}
+}
+
+// bic checks a SWIFT code: bank, country, location and an optional branch.
+func bic(_ string) Validator {
+	return func(ctx ValidationContext) (bool, error) {
+		code := ctx.FieldValue.String()
+		if (len(code) != 8 && len(code) != 11) || !isUpperAlpha(code[:6]) || !isUpperAlnum(code[6:]) {
+			verr := NewValidationError(
+				ctx,
+				"the field must be a BIC",
+				BICIdentifier)
+			if len(code) >= 6 && isUpperAlpha(code[4:6]) {
+				verr = verr.WithMetadata(CountryMetadata, code[4:6])
+			}
+			return false, verr
+		}
+		return true, nil
+	}
+}
+
+// isin checks a securities number: a country, nine characters and a
+// check digit computed with luhn over the digits of the code.
+func isin(_ string) Validator {
+	return func(ctx ValidationContext) (bool, error) {
+		code := ctx.FieldValue.String()
+		if len(code) != 12 || !isUpperAlpha(code[:2]) || !isUpperAlnum(code[2:11]) || !isDigits(code[11:]) {
+			return false, NewValidationError(
+				ctx,
+				"the field must be an ISIN",
+				ISINIdentifier)
+		}
+		var digits strings.Builder
+		for _, c := range code {
+			if c >= 'A' {
+				digits.WriteString(strconv.Itoa(int(c-'A') + 10))
+			} else {
+				digits.WriteRune(c)
+			}
+		}
+		if !luhn(digits.String()) {
+			return false, NewValidationError(
+				ctx,
+				"the field must be a valid ISIN",
+				ISINIdentifier).WithMetadata(CountryMetadata, code[:2])
+		}
+		return true, nil
+	}
+}
@@ -3,11 +3,13 @@ package valtruc
 import (
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 const (
-	MinFloat64Identifier ValidatorIdentifier = "minFloat64Identifier"
-	MaxFloat64Identifier ValidatorIdentifier = "maxFloat64Identifier"
+	MinFloat64Identifier     ValidatorIdentifier = "minFloat64Identifier"
+	MaxFloat64Identifier     ValidatorIdentifier = "maxFloat64Identifier"
+	BetweenFloat64Identifier ValidatorIdentifier = "betweenFloat64Identifier"
 )
 
 func minFloat64(param string) Validator {
@@ -45,3 +47,29 @@ func maxFloat64(param string) Validator {
 		return true, nil
 	}
 }
+
+func betweenFloat64(params []string) Validator {
+	if len(params) != 2 {
+		panic(fmt.Sprintf("between float64 needs two parameters, got %d", len(params)))
+	}
+	minv, err := strconv.ParseFloat(params[0], 64)
+	if err != nil {
+		panic(fmt.Sprintf("invalid between float64 %s", params[0]))
+	}
+	maxv, err := strconv.ParseFloat(params[1], 64)
+	if err != nil {
+		panic(fmt.Sprintf("invalid between float64 %s", params[1]))
+	}
+	param := strings.Join(params, string(paramSeparator))
+	return func(ctx ValidationContext) (bool, error) {
+		value := ctx.FieldValue.Float()
+		if value < minv || value > maxv {
+			return false, NewValidationErrorMeta(
+				ctx,
+				fmt.Sprintf("float must be between %f and %f", minv, maxv),
+				BetweenFloat64Identifier,
+				param)
+		}
+		return true, nil
+	}
+}
@@ -0,0 +1,48 @@
+package valtruc
+
+import "unicode"
+
+const zeroWidthJoiner = '\u200d'
+
+// graphemeCount counts the characters a user would see. It follows the
+// main rules of Unicode grapheme clusters (UAX #29): CR LF, combining
+// marks, emoji modifiers and tags, zero width joiner sequences, flags and
+// Hangul jamo. It is an approximation: prepend characters and some
+// emoji rules are not implemented.
+func graphemeCount(str string) int {
+	count := 0
+	prev := rune(-1)
+	pairedFlag := false
+	for _, c := range str {
+		join := false
+		switch {
+		case prev == -1:
+		case prev == '\r':
+			join = c == '\n'
+		case prev == '\n' || unicode.IsControl(prev):
+		case prev == zeroWidthJoiner, extendsGrapheme(c):
+			join = true
+		case isRegionalIndicator(prev) && isRegionalIndicator(c):
+			join = !pairedFlag
+		}
+
+		pairedFlag = join && isRegionalIndicator(c)
+		if !join {
+			count++
+		}
+		prev = c
+	}
+	return count
+}
+
+func extendsGrapheme(c rune) bool {
+	return unicode.In(c, unicode.Mn, unicode.Me, unicode.Mc) ||
+		c == zeroWidthJoiner ||
+		(c >= 0x1f3fb && c <= 0x1f3ff) || // emoji modifiers
+		(c >= 0xe0020 && c <= 0xe007f) || // tags
+		(c >= 0x1160 && c <= 0x11ff) // hangul jamo vowels and trailing consonants
+}
+
+func isRegionalIndicator(c rune) bool {
+	return c >= 0x1f1e6 && c <= 0x1f1ff
+}
@@ -0,0 +1,68 @@
+package valtruc
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+const groupsTagName = "valtruc_groups"
+
+// ValidateGroups validates target running the rules without group and
+// the rules of the given groups.
+func (vt Valtruc) ValidateGroups(target interface{}, groups ...string) []error {
+	t := reflect.TypeOf(target)
+	v := reflect.ValueOf(target)
+	run := vt.newRun()
+	run.groups = groups
+	return vt.validate(t, v, vt.compiledFor(t), run)
+}
+
+// withFieldGroups gives the groups in the valtruc_groups tag of a field
+// to the rules of that field that do not have their own groups.
+func withFieldGroups(tags []valTag, field reflect.StructField) []valTag {
+	value, ok := field.Tag.Lookup(groupsTagName)
+	if !ok {
+		return tags
+	}
+	groups := []string{}
+	for _, group := range strings.Split(value, ",") {
+		if group = strings.TrimSpace(group); len(group) > 0 {
+			groups = append(groups, group)
+		}
+	}
+	for i := range tags {
+		if len(tags[i].groups) == 0 {
+			tags[i].groups = groups
+		}
+	}
+	return tags
+}
+
+// compiledRule is a validator with the groups of its rule. Rules are kept
+// in tag order, so bail stops at the first failing rule of the tag.
+type compiledRule struct {
+	validator Validator
+	groups    []string
+}
+
+func (cValidation *compiledValidation) add(validator Validator, groups []string) {
+	cValidation.rules = append(cValidation.rules, compiledRule{
+		validator: validator,
+		groups:    groups,
+	})
+}
+
+// runsIn tells if a rule runs when validating groups: rules without group
+// always run, and the others when any of their groups is validated.
+func (rule compiledRule) runsIn(groups []string) bool {
+	if len(rule.groups) == 0 {
+		return true
+	}
+	for _, group := range groups {
+		if slices.Contains(rule.groups, group) {
+			return true
+		}
+	}
+	return false
+}
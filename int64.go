@@ -1,13 +1,19 @@
 package valtruc
 
 import (
+	"cmp"
 	"fmt"
+	"reflect"
+	"slices"
 	"strconv"
+	"strings"
 )
 
 const (
-	MinInt64Identifier ValidatorIdentifier = "minInt64Identifier"
-	MaxInt64Identifier ValidatorIdentifier = "maxInt64Identifier"
+	MinInt64Identifier     ValidatorIdentifier = "minInt64Identifier"
+	MaxInt64Identifier     ValidatorIdentifier = "maxInt64Identifier"
+	BetweenInt64Identifier ValidatorIdentifier = "betweenInt64Identifier"
+	OneOfInt64Identifier   ValidatorIdentifier = "oneOfInt64Identifier"
 )
 
 func minInt64(param string) Validator {
@@ -45,3 +51,65 @@ func maxInt64(param string) Validator {
 		return true, nil
 	}
 }
+
+// compareInt compares a signed or unsigned integer value with n.
+func compareInt(value reflect.Value, n int64) int {
+	if value.CanUint() {
+		if n < 0 {
+			return 1
+		}
+		return cmp.Compare(value.Uint(), uint64(n))
+	}
+	return cmp.Compare(value.Int(), n)
+}
+
+func betweenInt64(params []string) Validator {
+	if len(params) != 2 {
+		panic(fmt.Sprintf("between int64 needs two parameters, got %d", len(params)))
+	}
+	minv, err := strconv.ParseInt(params[0], 10, 64)
+	if err != nil {
+		panic(fmt.Sprintf("invalid between int64 %s", params[0]))
+	}
+	maxv, err := strconv.ParseInt(params[1], 10, 64)
+	if err != nil {
+		panic(fmt.Sprintf("invalid between int64 %s", params[1]))
+	}
+	param := strings.Join(params, string(paramSeparator))
+	return func(ctx ValidationContext) (bool, error) {
+		if compareInt(ctx.FieldValue, minv) < 0 || compareInt(ctx.FieldValue, maxv) > 0 {
+			return false, NewValidationErrorMeta(
+				ctx,
+				fmt.Sprintf("integer must be between %d and %d", minv, maxv),
+				BetweenInt64Identifier,
+				param)
+		}
+		return true, nil
+	}
+}
+
+func oneOfInt64(params []string) Validator {
+	if len(params) == 0 {
+		panic("oneof int64 must have at least one parameter")
+	}
+	allowed := make([]int64, len(params))
+	for i, param := range params {
+		value, err := strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			panic(fmt.Sprintf("invalid oneof int64 %s", param))
+		}
+		allowed[i] = value
+	}
+	param := strings.Join(params, string(paramSeparator))
+	return func(ctx ValidationContext) (bool, error) {
+		equal := func(n int64) bool { return compareInt(ctx.FieldValue, n) == 0 }
+		if !slices.ContainsFunc(allowed, equal) {
+			return false, NewValidationErrorMeta(
+				ctx,
+				fmt.Sprintf("integer must be one of %s", strings.Join(params, ", ")),
+				OneOfInt64Identifier,
+				param)
+		}
+		return true, nil
+	}
+}
@@ -0,0 +1,254 @@
+package valtruc
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	ISO3166Alpha2Identifier ValidatorIdentifier = "iso3166Alpha2Identifier"
+	ISO3166Alpha3Identifier ValidatorIdentifier = "iso3166Alpha3Identifier"
+	ISO4217Identifier       ValidatorIdentifier = "iso4217Identifier"
+	BCP47Identifier         ValidatorIdentifier = "bcp47Identifier"
+)
+
+// countries has the ISO 3166-1 alpha-2 codes and their alpha-3 code.
+var countries = map[string]string{
+	"AD": "AND", "AE": "ARE", "AF": "AFG", "AG": "ATG", "AI": "AIA", "AL": "ALB", "AM": "ARM", "AO": "AGO",
+	"AQ": "ATA", "AR": "ARG", "AS": "ASM", "AT": "AUT", "AU": "AUS", "AW": "ABW", "AX": "ALA", "AZ": "AZE",
+	"BA": "BIH", "BB": "BRB", "BD": "BGD", "BE": "BEL", "BF": "BFA", "BG": "BGR", "BH": "BHR", "BI": "BDI",
+	"BJ": "BEN", "BL": "BLM", "BM": "BMU", "BN": "BRN", "BO": "BOL", "BQ": "BES", "BR": "BRA", "BS": "BHS",
+	"BT": "BTN", "BV": "BVT", "BW": "BWA", "BY": "BLR", "BZ": "BLZ", "CA": "CAN", "CC": "CCK", "CD": "COD",
+	"CF": "CAF", "CG": "COG", "CH": "CHE", "CI": "CIV", "CK": "COK", "CL": "CHL", "CM": "CMR", "CN": "CHN",
+	"CO": "COL", "CR": "CRI", "CU": "CUB", "CV": "CPV", "CW": "CUW", "CX": "CXR", "CY": "CYP", "CZ": "CZE",
+	"DE": "DEU", "DJ": "DJI", "DK": "DNK", "DM": "DMA", "DO": "DOM", "DZ": "DZA", "EC": "ECU", "EE": "EST",
+	"EG": "EGY", "EH": "ESH", "ER": "ERI", "ES": "ESP", "ET": "ETH", "FI": "FIN", "FJ": "FJI", "FK": "FLK",
+	"FM": "FSM", "FO": "FRO", "FR": "FRA", "GA": "GAB", "GB": "GBR", "GD": "GRD", "GE": "GEO", "GF": "GUF",
+	"GG": "GGY", "GH": "GHA", "GI": "GIB", "GL": "GRL", "GM": "GMB", "GN": "GIN", "GP": "GLP", "GQ": "GNQ",
+	"GR": "GRC", "GS": "SGS", "GT": "GTM", "GU": "GUM", "GW": "GNB", "GY": "GUY", "HK": "HKG", "HM": "HMD",
+	"HN": "HND", "HR": "HRV", "HT": "HTI", "HU": "HUN", "ID": "IDN", "IE": "IRL", "IL": "ISR", "IM": "IMN",
+	"IN": "IND", "IO": "IOT", "IQ": "IRQ", "IR": "IRN", "IS": "ISL", "IT": "ITA", "JE": "JEY", "JM": "JAM",
+	"JO": "JOR", "JP": "JPN", "KE": "KEN", "KG": "KGZ", "KH": "KHM", "KI": "KIR", "KM": "COM", "KN": "KNA",
+	"KP": "PRK", "KR": "KOR", "KW": "KWT", "KY": "CYM", "KZ": "KAZ", "LA": "LAO", "LB": "LBN", "LC": "LCA",
+	"LI": "LIE", "LK": "LKA", "LR": "LBR", "LS": "LSO", "LT": "LTU", "LU": "LUX", "LV": "LVA", "LY": "LBY",
+	"MA": "MAR", "MC": "MCO", "MD": "MDA", "ME": "MNE", "MF": "MAF", "MG": "MDG", "MH": "MHL", "MK": "MKD",
+	"ML": "MLI", "MM": "MMR", "MN": "MNG", "MO": "MAC", "MP": "MNP", "MQ": "MTQ", "MR": "MRT", "MS": "MSR",
+	"MT": "MLT", "MU": "MUS", "MV": "MDV", "MW": "MWI", "MX": "MEX", "MY": "MYS", "MZ": "MOZ", "NA": "NAM",
+	"NC": "NCL", "NE": "NER", "NF": "NFK", "NG": "NGA", "NI": "NIC", "NL": "NLD", "NO": "NOR", "NP": "NPL",
+	"NR": "NRU", "NU": "NIU", "NZ": "NZL", "OM": "OMN", "PA": "PAN", "PE": "PER", "PF": "PYF", "PG": "PNG",
+	"PH": "PHL", "PK": "PAK", "PL": "POL", "PM": "SPM", "PN": "PCN", "PR": "PRI", "PS": "PSE", "PT": "PRT",
+	"PW": "PLW", "PY": "PRY", "QA": "QAT", "RE": "REU", "RO": "ROU", "RS": "SRB", "RU": "RUS", "RW": "RWA",
+	"SA": "SAU", "SB": "SLB", "SC": "SYC", "SD": "SDN", "SE": "SWE", "SG": "SGP", "SH": "SHN", "SI": "SVN",
+	"SJ": "SJM", "SK": "SVK", "SL": "SLE", "SM": "SMR", "SN": "SEN", "SO": "SOM", "SR": "SUR", "SS": "SSD",
+	"ST": "STP", "SV": "SLV", "SX": "SXM", "SY": "SYR", "SZ": "SWZ", "TC": "TCA", "TD": "TCD", "TF": "ATF",
+	"TG": "TGO", "TH": "THA", "TJ": "TJK", "TK": "TKL", "TL": "TLS", "TM": "TKM", "TN": "TUN", "TO": "TON",
+	"TR": "TUR", "TT": "TTO", "TV": "TUV", "TW": "TWN", "TZ": "TZA", "UA": "UKR", "UG": "UGA", "UM": "UMI",
+	"US": "USA", "UY": "URY", "UZ": "UZB", "VA": "VAT", "VC": "VCT", "VE": "VEN", "VG": "VGB", "VI": "VIR",
+	"VN": "VNM", "VU": "VUT", "WF": "WLF", "WS": "WSM", "YE": "YEM", "YT": "MYT", "ZA": "ZAF", "ZM": "ZMB",
+	"ZW": "ZWE",
+}
+
+// currencies has the active ISO 4217 codes, including funds and precious
+// metals.
+var currencies = []string{
+	"AED", "AFN", "ALL", "AMD", "AOA", "ARS", "AUD", "AWG", "AZN", "BAM", "BBD", "BDT", "BGN", "BHD",
+	"BIF", "BMD", "BND", "BOB", "BOV", "BRL", "BSD", "BTN", "BWP", "BYN", "BZD", "CAD", "CDF", "CHE",
+	"CHF", "CHW", "CLF", "CLP", "CNY", "COP", "COU", "CRC", "CUP", "CVE", "CZK", "DJF", "DKK", "DOP",
+	"DZD", "EGP", "ERN", "ETB", "EUR", "FJD", "FKP", "GBP", "GEL", "GHS", "GIP", "GMD", "GNF", "GTQ",
+	"GYD", "HKD", "HNL", "HTG", "HUF", "IDR", "ILS", "INR", "IQD", "IRR", "ISK", "JMD", "JOD", "JPY",
+	"KES", "KGS", "KHR", "KMF", "KPW", "KRW", "KWD", "KYD", "KZT", "LAK", "LBP", "LKR", "LRD", "LSL",
+	"LYD", "MAD", "MDL", "MGA", "MKD", "MMK", "MNT", "MOP", "MRU", "MUR", "MVR", "MWK", "MXN", "MXV",
+	"MYR", "MZN", "NAD", "NGN", "NIO", "NOK", "NPR", "NZD", "OMR", "PAB", "PEN", "PGK", "PHP", "PKR",
+	"PLN", "PYG", "QAR", "RON", "RSD", "RUB", "RWF", "SAR", "SBD", "SCR", "SDG", "SEK", "SGD", "SHP",
+	"SLE", "SOS", "SRD", "SSP", "STN", "SVC", "SYP", "SZL", "THB", "TJS", "TMT", "TND", "TOP", "TRY",
+	"TTD", "TWD", "TZS", "UAH", "UGX", "USD", "USN", "UYI", "UYU", "UYW", "UZS", "VED", "VES", "VND",
+	"VUV", "WST", "XAF", "XAG", "XAU", "XBA", "XBB", "XBC", "XBD", "XCD", "XCG", "XDR", "XOF", "XPD",
+	"XPF", "XPT", "XSU", "XTS", "XUA", "XXX", "YER", "ZAR", "ZMW", "ZWG",
+}
+
+// languages has the ISO 639-1 codes, used as two letter languages in
+// BCP 47 tags.
+var languages = []string{
+	"aa", "ab", "ae", "af", "ak", "am", "an", "ar", "as", "av", "ay", "az", "ba", "be", "bg", "bi",
+	"bm", "bn", "bo", "br", "bs", "ca", "ce", "ch", "co", "cr", "cs", "cu", "cv", "cy", "da", "de",
+	"dv", "dz", "ee", "el", "en", "eo", "es", "et", "eu", "fa", "ff", "fi", "fj", "fo", "fr", "fy",
+	"ga", "gd", "gl", "gn", "gu", "gv", "ha", "he", "hi", "ho", "hr", "ht", "hu", "hy", "hz", "ia",
+	"id", "ie", "ig", "ii", "ik", "io", "is", "it", "iu", "ja", "jv", "ka", "kg", "ki", "kj", "kk",
+	"kl", "km", "kn", "ko", "kr", "ks", "ku", "kv", "kw", "ky", "la", "lb", "lg", "li", "ln", "lo",
+	"lt", "lu", "lv", "mg", "mh", "mi", "mk", "ml", "mn", "mr", "ms", "mt", "my", "na", "nb", "nd",
+	"ne", "ng", "nl", "nn", "no", "nr", "nv", "ny", "oc", "oj", "om", "or", "os", "pa", "pi", "pl",
+	"ps", "pt", "qu", "rm", "rn", "ro", "ru", "rw", "sa", "sc", "sd", "se", "sg", "si", "sk", "sl",
+	"sm", "sn", "so", "sq", "sr", "ss", "st", "su", "sv", "sw", "ta", "te", "tg", "th", "ti", "tk",
+	"tl", "tn", "to", "tr", "ts", "tt", "tw", "ty", "ug", "uk", "ur", "uz", "ve", "vi", "vo", "wa",
+	"wo", "xh", "yi", "yo", "za", "zh", "zu",
+}
+
+var countriesAlpha3 = func() map[string]bool {
+	codes := map[string]bool{}
+	for _, alpha3 := range countries {
+		codes[alpha3] = true
+	}
+	return codes
+}()
+
+func isCountryAlpha2(code string) bool {
+	_, ok := countries[code]
+	return ok
+}
+
+func isCountryAlpha3(code string) bool {
+	return countriesAlpha3[code]
+}
+
+func isCurrency(code string) bool {
+	return slices.Contains(currencies, code)
+}
+
+// codeValidator builds validators for codes in a table. Params restrict
+// the valid codes to a subset, like "iso4217=EUR|USD".
+func codeValidator(valid func(string) bool, what string, identifier ValidatorIdentifier) ParamsValidatorConstructor {
+	return func(params []string) Validator {
+		for _, param := range params {
+			if !valid(param) {
+				panic(fmt.Sprintf("invalid %s %s", what, param))
+			}
+		}
+		param := strings.Join(params, string(paramSeparator))
+		msg := fmt.Sprintf("the field must be an %s", what)
+		if len(params) > 0 {
+			msg = fmt.Sprintf("the field must be one of %s", strings.Join(params, ", "))
+		}
+		return func(ctx ValidationContext) (bool, error) {
+			code := ctx.FieldValue.String()
+			if !valid(code) || (len(params) > 0 && !slices.Contains(params, code)) {
+				return false, NewValidationErrorMeta(ctx, msg, identifier, param)
+			}
+			return true, nil
+		}
+	}
+}
+
+func iso3166Alpha2(params []string) Validator {
+	return codeValidator(isCountryAlpha2, "ISO 3166 country code", ISO3166Alpha2Identifier)(params)
+}
+
+func iso3166Alpha3(params []string) Validator {
+	return codeValidator(isCountryAlpha3, "ISO 3166 alpha-3 country code", ISO3166Alpha3Identifier)(params)
+}
+
+func iso4217(params []string) Validator {
+	return codeValidator(isCurrency, "ISO 4217 currency code", ISO4217Identifier)(params)
+}
+
+// bcp47Language gives the language of a BCP 47 tag like "es", "es-ES",
+// "zh-Hant-TW" or "sl-rozaj-biske". Two letter languages and regions must
+// be in the ISO tables. Tags with only private use subtags ("x-klingon")
+// have no language. Grandfathered tags are not supported.
+func bcp47Language(tag string) (string, bool) {
+	subtags := strings.Split(strings.ToLower(tag), "-")
+	for _, subtag := range subtags {
+		if len(subtag) == 0 || len(subtag) > 8 || !isLowerAlnum(subtag) {
+			return "", false
+		}
+	}
+	if subtags[0] == "x" {
+		return "", len(subtags) > 1
+	}
+
+	language := subtags[0]
+	switch {
+	case len(language) == 2 && slices.Contains(languages, language):
+	case len(language) == 3 && isLowerAlpha(language):
+	default:
+		return "", false
+	}
+
+	i := 1
+	next := func(matches func(string) bool) bool {
+		if i < len(subtags) && matches(subtags[i]) {
+			i++
+			return true
+		}
+		return false
+	}
+	extlang := func(s string) bool { return len(s) == 3 && isLowerAlpha(s) }
+	script := func(s string) bool { return len(s) == 4 && isLowerAlpha(s) }
+	region := func(s string) bool {
+		return (len(s) == 2 && isCountryAlpha2(strings.ToUpper(s))) || (len(s) == 3 && isDigits(s))
+	}
+	variant := func(s string) bool {
+		return len(s) >= 5 || (len(s) == 4 && s[0] >= '0' && s[0] <= '9')
+	}
+
+	for range 3 {
+		if !next(extlang) {
+			break
+		}
+	}
+	next(script)
+	next(region)
+	for next(variant) {
+	}
+
+	// Extensions are a singleton followed by subtags of two or more
+	// characters. Private use ("x-...") takes the rest of the tag.
+	for i < len(subtags) {
+		singleton := subtags[i]
+		if len(singleton) != 1 {
+			return "", false
+		}
+		i++
+		if singleton == "x" {
+			return language, i < len(subtags)
+		}
+		start := i
+		for i < len(subtags) && len(subtags[i]) >= 2 {
+			i++
+		}
+		if i == start {
+			return "", false
+		}
+	}
+	return language, true
+}
+
+func isLowerAlnum(str string) bool {
+	for _, c := range str {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'z') {
+			return false
+		}
+	}
+	return true
+}
+
+func isLowerAlpha(str string) bool {
+	for _, c := range str {
+		if c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// bcp47 checks language tags. Params restrict the valid languages, so
+// "bcp47=es|en" accepts "es-ES" and "en-GB" but not "fr-FR".
+func bcp47(params []string) Validator {
+	for _, param := range params {
+		if language, ok := bcp47Language(param); !ok || language != param {
+			panic(fmt.Sprintf("invalid BCP 47 language %s", param))
+		}
+	}
+	param := strings.Join(params, string(paramSeparator))
+	msg := "the field must be a BCP 47 language tag"
+	if len(params) > 0 {
+		msg = fmt.Sprintf("the field must be a BCP 47 language tag for %s", strings.Join(params, ", "))
+	}
+	return func(ctx ValidationContext) (bool, error) {
+		language, ok := bcp47Language(ctx.FieldValue.String())
+		if !ok || (len(params) > 0 && !slices.Contains(params, language)) {
+			return false, NewValidationErrorMeta(ctx, msg, BCP47Identifier, param)
+		}
+		return true, nil
+	}
+}
@@ -0,0 +1,196 @@
+package valtruc
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	IPIdentifier       ValidatorIdentifier = "ipIdentifier"
+	IPv4Identifier     ValidatorIdentifier = "ipv4Identifier"
+	IPv6Identifier     ValidatorIdentifier = "ipv6Identifier"
+	CIDRIdentifier     ValidatorIdentifier = "cidrIdentifier"
+	MACIdentifier      ValidatorIdentifier = "macIdentifier"
+	HostPortIdentifier ValidatorIdentifier = "hostPortIdentifier"
+	PortIdentifier     ValidatorIdentifier = "portIdentifier"
+	IPInIdentifier     ValidatorIdentifier = "ipInIdentifier"
+)
+
+var (
+	addrType   = reflect.TypeFor[netip.Addr]()
+	prefixType = reflect.TypeFor[netip.Prefix]()
+)
+
+// prefixOf reads a netip.Addr, a netip.Prefix or a string with any of
+// them. Addresses are returned as a prefix with all their bits.
+func prefixOf(value reflect.Value) (netip.Prefix, bool) {
+	switch value.Type() {
+	case addrType:
+		addr := value.Interface().(netip.Addr)
+		return netip.PrefixFrom(addr, addr.BitLen()), addr.IsValid()
+	case prefixType:
+		prefix := value.Interface().(netip.Prefix)
+		return prefix, prefix.IsValid()
+	}
+
+	str := value.String()
+	if strings.Contains(str, "/") {
+		prefix, err := netip.ParsePrefix(str)
+		return prefix, err == nil
+	}
+	addr, err := netip.ParseAddr(str)
+	return netip.PrefixFrom(addr, addr.BitLen()), err == nil
+}
+
+func addrOf(value reflect.Value) (netip.Addr, bool) {
+	if value.Kind() == reflect.String {
+		addr, err := netip.ParseAddr(value.String())
+		return addr, err == nil
+	}
+	prefix, ok := prefixOf(value)
+	return prefix.Addr(), ok
+}
+
+func ipValidator(is func(netip.Addr) bool, msg string, identifier ValidatorIdentifier) ValidatorConstructor {
+	return func(_ string) Validator {
+		return func(ctx ValidationContext) (bool, error) {
+			addr, ok := addrOf(ctx.FieldValue)
+			if !ok || !is(addr) {
+				return false, NewValidationError(ctx, msg, identifier)
+			}
+			return true, nil
+		}
+	}
+}
+
+func ip(param string) Validator {
+	return ipValidator(netip.Addr.IsValid, "the field must be an IP address", IPIdentifier)(param)
+}
+
+func ipv4(param string) Validator {
+	return ipValidator(netip.Addr.Is4, "the field must be an IPv4 address", IPv4Identifier)(param)
+}
+
+func ipv6(param string) Validator {
+	return ipValidator(netip.Addr.Is6, "the field must be an IPv6 address", IPv6Identifier)(param)
+}
+
+func cidr(_ string) Validator {
+	return func(ctx ValidationContext) (bool, error) {
+		if !isCIDR(ctx.FieldValue) {
+			return false, NewValidationError(
+				ctx,
+				"the field must be a network in CIDR notation",
+				CIDRIdentifier)
+		}
+		return true, nil
+	}
+}
+
+func isCIDR(value reflect.Value) bool {
+	if value.Type() == prefixType {
+		return value.Interface().(netip.Prefix).IsValid()
+	}
+	_, err := netip.ParsePrefix(value.String())
+	return err == nil
+}
+
+func mac(_ string) Validator {
+	return func(ctx ValidationContext) (bool, error) {
+		if _, err := net.ParseMAC(ctx.FieldValue.String()); err != nil {
+			return false, NewValidationError(
+				ctx,
+				"the field must be a MAC address",
+				MACIdentifier)
+		}
+		return true, nil
+	}
+}
+
+func isPort(str string) bool {
+	port, err := strconv.ParseUint(str, 10, 16)
+	return err == nil && port > 0
+}
+
+func port(_ string) Validator {
+	return func(ctx ValidationContext) (bool, error) {
+		if !isPort(ctx.FieldValue.String()) {
+			return false, NewValidationError(
+				ctx,
+				"the field must be a port from 1 to 65535",
+				PortIdentifier)
+		}
+		return true, nil
+	}
+}
+
+// hostPort checks values like "example.com:80", "10.0.0.1:80" or "[::1]:80".
+func hostPort(_ string) Validator {
+	return func(ctx ValidationContext) (bool, error) {
+		host, port, err := net.SplitHostPort(ctx.FieldValue.String())
+		if err != nil || !isPort(port) || !isHost(host) {
+			return false, NewValidationError(
+				ctx,
+				"the field must be a host and a port",
+				HostPortIdentifier)
+		}
+		return true, nil
+	}
+}
+
+func isHost(host string) bool {
+	if _, err := netip.ParseAddr(host); err == nil {
+		return true
+	}
+	if len(host) == 0 || len(host) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(strings.TrimSuffix(host, "."), ".") {
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			isAlnum := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+			if !isAlnum && c != '-' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ipIn checks that an address (or every address of a network) is inside
+// any of the networks given as params, like "ip_in=10.0.0.0/8|192.168.0.0/16".
+func ipIn(params []string) Validator {
+	if len(params) == 0 {
+		panic("ip_in must have at least one network")
+	}
+	networks := make([]netip.Prefix, len(params))
+	for i, param := range params {
+		network, err := netip.ParsePrefix(param)
+		if err != nil {
+			panic(fmt.Sprintf("invalid ip_in network %s", param))
+		}
+		networks[i] = network.Masked()
+	}
+	param := strings.Join(params, string(paramSeparator))
+	return func(ctx ValidationContext) (bool, error) {
+		prefix, ok := prefixOf(ctx.FieldValue)
+		if ok {
+			for _, network := range networks {
+				if network.Bits() <= prefix.Bits() && network.Contains(prefix.Addr()) {
+					return true, nil
+				}
+			}
+		}
+		return false, NewValidationErrorMeta(
+			ctx,
+			fmt.Sprintf("the address must be in %s", strings.Join(params, ", ")),
+			IPInIdentifier,
+			param)
+	}
+}
@@ -0,0 +1,55 @@
+package valtruc
+
+import (
+	"database/sql/driver"
+	"reflect"
+)
+
+type unwrapper struct {
+	inner  reflect.Type
+	unwrap func(value reflect.Value) (reflect.Value, bool)
+}
+
+var valuerType = reflect.TypeFor[driver.Valuer]()
+
+// AddUnwrapper lets fields of type O be validated with the rules of T,
+// like a generic Option[T]. When unwrap returns false the field is
+// treated like a nil pointer: only required fails.
+func AddUnwrapper[O, T any](vt *Valtruc, unwrap func(O) (T, bool)) {
+	vt.unwrappers[reflect.TypeFor[O]()] = unwrapper{
+		inner: reflect.TypeFor[T](),
+		unwrap: func(value reflect.Value) (reflect.Value, bool) {
+			inner, ok := unwrap(value.Interface().(O))
+			return reflect.ValueOf(&inner).Elem(), ok
+		},
+	}
+}
+
+func (vt Valtruc) unwrapperFor(t reflect.Type) (unwrapper, bool) {
+	if u, ok := vt.unwrappers[t]; ok {
+		return u, true
+	}
+	return valuerUnwrapper(t)
+}
+
+// valuerUnwrapper unwraps driver.Valuer structs shaped like the
+// database/sql Null types: a value and a Valid bool.
+func valuerUnwrapper(t reflect.Type) (unwrapper, bool) {
+	if t.Kind() != reflect.Struct || t.NumField() != 2 {
+		return unwrapper{}, false
+	}
+	if !t.Implements(valuerType) && !reflect.PointerTo(t).Implements(valuerType) {
+		return unwrapper{}, false
+	}
+	valid, ok := t.FieldByName("Valid")
+	if !ok || valid.Type.Kind() != reflect.Bool {
+		return unwrapper{}, false
+	}
+	index := 1 - valid.Index[0]
+	return unwrapper{
+		inner: t.Field(index).Type,
+		unwrap: func(value reflect.Value) (reflect.Value, bool) {
+			return value.Field(index), value.Field(valid.Index[0]).Bool()
+		},
+	}, true
+}
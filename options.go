@@ -0,0 +1,135 @@
+package valtruc
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+type Option func(vt *Valtruc)
+
+// WithTagName reads rules from the given struct tag instead of "valtruc".
+func WithTagName(name string) Option {
+	return WithTagNames(name)
+}
+
+// WithTagNames reads rules from the first of the given struct tags present
+// in each field, so structs can be migrated from one tag to another.
+func WithTagNames(names ...string) Option {
+	return func(vt *Valtruc) {
+		vt.tagNames = names
+	}
+}
+
+// FieldNameResolver gives the name used for a field in errors and paths,
+// for example the name of its json tag. Empty names fall back to the
+// Go field name.
+type FieldNameResolver func(field reflect.StructField) string
+
+// Translator gives the message of a validation error, for example
+// in the language of the user.
+type Translator func(verr ValidationError) string
+
+func WithFieldNameResolver(resolver FieldNameResolver) Option {
+	return func(vt *Valtruc) {
+		vt.fieldName = resolver
+	}
+}
+
+func WithTranslator(translator Translator) Option {
+	return func(vt *Valtruc) {
+		vt.translator = translator
+	}
+}
+
+// WithStrict tells if unknown rules should panic (the default) or be
+// ignored, which is useful when migrating tags from another validator.
+func WithStrict(strict bool) Option {
+	return func(vt *Valtruc) {
+		vt.strict = strict
+	}
+}
+
+// WithFailFast stops validating at the first error.
+func WithFailFast() Option {
+	return WithMaxErrors(1)
+}
+
+// WithMaxErrors stops validating after n errors. Zero means no limit and
+// negative values panic.
+func WithMaxErrors(n int) Option {
+	if n < 0 {
+		panic(fmt.Sprintf("valtruc: max errors cannot be negative, got %d", n))
+	}
+	return func(vt *Valtruc) {
+		vt.maxErrors = n
+	}
+}
+
+// WithParallelism validates slice elements, nested structs and fields
+// with context aware validators in up to n goroutines. Errors are
+// returned in the same order as when validating sequentially.
+func WithParallelism(n int) Option {
+	return func(vt *Valtruc) {
+		vt.parallelism = n
+	}
+}
+
+// WithByteLengths makes the string min and max validators count bytes, as
+// they did before counting runes. Use minbytes and maxbytes to count bytes
+// only in some fields.
+func WithByteLengths() Option {
+	return func(vt *Valtruc) {
+		vt.validators[reflect.String]["min"] = withParam(minLength(byteCount, "", MinStringLengthIdentifier))
+		vt.validators[reflect.String]["max"] = withParam(maxLength(byteCount, "", MaxStringLengthIdentifier))
+	}
+}
+
+func (vt Valtruc) resolveFieldName(field reflect.StructField) string {
+	if vt.fieldName == nil {
+		return field.Name
+	}
+	if name := vt.fieldName(field); len(name) > 0 {
+		return name
+	}
+	return field.Name
+}
+
+// Clone returns a copy of vt with its own validators, aliases and rules,
+// so they can be changed without affecting vt.
+func (vt Valtruc) Clone() Valtruc {
+	validators := make(map[reflect.Kind]map[string]ParamsValidatorConstructor, len(vt.validators))
+	for kind, forKind := range vt.validators {
+		validators[kind] = maps.Clone(forKind)
+	}
+	typeValidators := make(map[reflect.Type]map[string]ParamsValidatorConstructor, len(vt.typeValidators))
+	for t, forType := range vt.typeValidators {
+		typeValidators[t] = maps.Clone(forType)
+	}
+	rules := make(map[reflect.Type]map[string]string, len(vt.rules))
+	for t, fields := range vt.rules {
+		rules[t] = maps.Clone(fields)
+	}
+	ctxNames := make(map[reflect.Kind]map[string]bool, len(vt.ctxNames))
+	for kind, names := range vt.ctxNames {
+		ctxNames[kind] = maps.Clone(names)
+	}
+	typeChecks := make(map[reflect.Kind]map[string]func(reflect.Type) bool, len(vt.typeChecks))
+	for kind, checks := range vt.typeChecks {
+		typeChecks[kind] = maps.Clone(checks)
+	}
+
+	clone := vt
+	clone.compiled = map[reflect.Type]map[string]compiledValidation{}
+	clone.vars = map[varKey]compiledValidation{}
+	clone.validators = validators
+	clone.typeValidators = typeValidators
+	clone.rules = rules
+	clone.ctxNames = ctxNames
+	clone.typeChecks = typeChecks
+	clone.aliases = maps.Clone(vt.aliases)
+	clone.tagNames = slices.Clone(vt.tagNames)
+	clone.unwrappers = maps.Clone(vt.unwrappers)
+	return clone
+}
@@ -0,0 +1,79 @@
+package valtruc
+
+import "sync"
+
+func (run *validationRun) child(parent []*validationRun, index int) *validationRun {
+	return &validationRun{
+		ctx:       run.ctx,
+		errs:      []error{},
+		maxErrors: run.maxErrors,
+		filter:    run.filter,
+		groups:    run.groups,
+		sem:       run.sem,
+		failures:  run.failures,
+		parent:    run,
+		offset:    len(run.errs),
+		siblings:  parent,
+		index:     index,
+	}
+}
+
+// preceding gives how many errors go before the errors of run in the
+// result. Errors of runs still working are counted before being trimmed,
+// so a run only stops when the errors before it already fill the limit,
+// like it would when validating sequentially.
+func (run *validationRun) preceding() int64 {
+	if run.parent == nil {
+		return 0
+	}
+	total := run.parent.preceding() + int64(run.offset)
+	for _, sibling := range run.siblings[:run.index] {
+		total += sibling.count.Load()
+	}
+	return total
+}
+
+// parallel runs work for each index in its own run, in a new goroutine
+// when heavy says it is worth it and there is room for one, and then adds
+// their errors in index order. When there is no room the work runs in the
+// calling goroutine, so nested calls never wait for each other.
+func (run *validationRun) parallel(n int, heavy func(i int) bool, work func(i int, run *validationRun)) {
+	children := make([]*validationRun, n)
+	for i := range n {
+		children[i] = run.child(children, i)
+	}
+
+	var wg sync.WaitGroup
+	for i, child := range children {
+		if run.done() {
+			break
+		}
+		if !heavy(i) {
+			work(i, child)
+			continue
+		}
+		select {
+		case run.sem <- struct{}{}:
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-run.sem }()
+				work(i, child)
+			}()
+		default:
+			work(i, child)
+		}
+	}
+	wg.Wait()
+
+	// Errors of the children are already counted, so they are appended
+	// without add.
+	for _, child := range children {
+		for _, err := range child.errs {
+			if run.maxErrors > 0 && len(run.errs) >= run.maxErrors {
+				return
+			}
+			run.errs = append(run.errs, err)
+		}
+	}
+}
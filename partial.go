@@ -0,0 +1,58 @@
+package valtruc
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+var pathIndexes = regexp.MustCompile(`\[\d+\]`)
+
+type pathFilter struct {
+	paths  []string
+	except bool
+}
+
+// ValidatePartial only validates the fields in paths, written like the
+// ones in ValidationError.Path (eg. "Name", "Address.Zip", "Items[0].Name").
+// A path without indexes ("Items.Name") selects every element of a slice.
+func (vt Valtruc) ValidatePartial(target interface{}, paths ...string) []error {
+	return vt.validateFiltered(target, pathFilter{paths: paths})
+}
+
+// ValidateExcept validates every field but the ones in paths.
+func (vt Valtruc) ValidateExcept(target interface{}, paths ...string) []error {
+	return vt.validateFiltered(target, pathFilter{paths: paths, except: true})
+}
+
+func (vt Valtruc) validateFiltered(target interface{}, filter pathFilter) []error {
+	t := reflect.TypeOf(target)
+	v := reflect.ValueOf(target)
+	run := vt.newRun()
+	run.filter = &filter
+	return vt.validate(t, v, vt.compiledFor(t), run)
+}
+
+func (filter pathFilter) selects(path string) (rules bool, recurse bool) {
+	withoutIndexes := pathIndexes.ReplaceAllString(path, "")
+	for _, selected := range filter.paths {
+		if isSameOrInside(path, selected) || isSameOrInside(withoutIndexes, selected) {
+			return !filter.except, !filter.except
+		}
+		if !filter.except && (isInside(selected, path) || isInside(selected, withoutIndexes)) {
+			recurse = true
+		}
+	}
+	if filter.except {
+		return true, true
+	}
+	return false, recurse
+}
+
+func isSameOrInside(path, parent string) bool {
+	return path == parent || isInside(path, parent)
+}
+
+func isInside(path, parent string) bool {
+	return strings.HasPrefix(path, parent+".") || strings.HasPrefix(path, parent+"[")
+}
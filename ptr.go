@@ -3,25 +3,36 @@ package valtruc
 import "reflect"
 
 func ptrValidatorWrapper(inner Validator, tag valTag) Validator {
-	return func(ctx ValidationContext) (bool, error) {
-		if ctx.FieldValue.Type().Kind() != reflect.Ptr {
-			return inner(ctx)
-		}
+	return optionalValidatorWrapper(inner, tag, "the field pointer is nil and is required", derefPtr)
+}
+
+func derefPtr(value reflect.Value) (reflect.Value, bool) {
+	if value.Type().Kind() != reflect.Ptr {
+		return value, true
+	}
+	if value.IsNil() {
+		return value, false
+	}
+	return value.Elem(), true
+}
 
-		z := reflect.Zero(ctx.FieldValue.Type())
-		isZero := ctx.FieldValue.Interface() == z.Interface()
-		if isZero {
-			if tag.name == "required" {
+// optionalValidatorWrapper runs inner with the value given by unwrap.
+// When there is no value, only required fails and !required passes.
+func optionalValidatorWrapper(inner Validator, tag valTag, requiredMsg string, unwrap func(reflect.Value) (reflect.Value, bool)) Validator {
+	return func(ctx ValidationContext) (bool, error) {
+		value, ok := unwrap(ctx.FieldValue)
+		if !ok {
+			if tag.name == "required" && !tag.negated {
 				return false, NewValidationError(
 					ctx,
-					"the field pointer is nil and is required",
+					requiredMsg,
 					RequiredIdentifier)
 			} else {
 				return true, nil
 			}
 		}
 
-		ctx.FieldValue = ctx.FieldValue.Elem()
+		ctx.FieldValue = value
 		return inner(ctx)
 	}
 }
@@ -2,65 +2,285 @@ package valtruc
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 const (
-	MinStringLengthIdentifier ValidatorIdentifier = "minStringLengthIdentifier"
-	MaxStringLengthIdentifier ValidatorIdentifier = "maxStringLengthIdentifier"
-	ContainsStringIdentifier  ValidatorIdentifier = "containsStringIdentifier"
+	MinStringLengthIdentifier    ValidatorIdentifier = "minStringLengthIdentifier"
+	MaxStringLengthIdentifier    ValidatorIdentifier = "maxStringLengthIdentifier"
+	MinStringBytesIdentifier     ValidatorIdentifier = "minStringBytesIdentifier"
+	MaxStringBytesIdentifier     ValidatorIdentifier = "maxStringBytesIdentifier"
+	MinStringGraphemesIdentifier ValidatorIdentifier = "minStringGraphemesIdentifier"
+	MaxStringGraphemesIdentifier ValidatorIdentifier = "maxStringGraphemesIdentifier"
+	ContainsStringIdentifier     ValidatorIdentifier = "containsStringIdentifier"
+	OneOfStringIdentifier        ValidatorIdentifier = "oneOfStringIdentifier"
+	RegexStringIdentifier        ValidatorIdentifier = "regexStringIdentifier"
+	AlphaStringIdentifier        ValidatorIdentifier = "alphaStringIdentifier"
+	AlphanumStringIdentifier     ValidatorIdentifier = "alphanumStringIdentifier"
+	NumericStringIdentifier      ValidatorIdentifier = "numericStringIdentifier"
+	ASCIIStringIdentifier        ValidatorIdentifier = "asciiStringIdentifier"
+	PrintASCIIStringIdentifier   ValidatorIdentifier = "printASCIIStringIdentifier"
+	LowercaseStringIdentifier    ValidatorIdentifier = "lowercaseStringIdentifier"
+	UppercaseStringIdentifier    ValidatorIdentifier = "uppercaseStringIdentifier"
+	StartsWithStringIdentifier   ValidatorIdentifier = "startsWithStringIdentifier"
+	EndsWithStringIdentifier     ValidatorIdentifier = "endsWithStringIdentifier"
+	ExcludesStringIdentifier     ValidatorIdentifier = "excludesStringIdentifier"
+	ExcludesAllStringIdentifier  ValidatorIdentifier = "excludesAllStringIdentifier"
+	ContainsAnyStringIdentifier  ValidatorIdentifier = "containsAnyStringIdentifier"
 )
 
+// minLength and maxLength build length validators. count tells how the
+// length is measured: runes, bytes or graphemes.
+func minLength(count func(string) int, unit string, identifier ValidatorIdentifier) ValidatorConstructor {
+	return func(param string) Validator {
+		minv, err := strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			panic(fmt.Sprintf("invalid min length string %s", param))
+		}
+		return func(ctx ValidationContext) (bool, error) {
+			value := ctx.FieldValue.String()
+			if count(value) < int(minv) {
+				return false, NewValidationErrorMeta(
+					ctx,
+					fmt.Sprintf("the field required minimum length of %d%s", minv, unit),
+					identifier,
+					param)
+			}
+			return true, nil
+		}
+	}
+}
+
+func maxLength(count func(string) int, unit string, identifier ValidatorIdentifier) ValidatorConstructor {
+	return func(param string) Validator {
+		maxv, err := strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			panic(fmt.Sprintf("invalid max length string %s", param))
+		}
+		return func(ctx ValidationContext) (bool, error) {
+			value := ctx.FieldValue.String()
+			if count(value) > int(maxv) {
+				return false, NewValidationErrorMeta(
+					ctx,
+					fmt.Sprintf("the field required maximum length of %d%s", maxv, unit),
+					identifier,
+					param)
+			}
+			return true, nil
+		}
+	}
+}
+
+func byteCount(str string) int {
+	return len(str)
+}
+
+// minStringLength and maxStringLength count runes, so "José" has length 4.
 func minStringLength(param string) Validator {
-	minv, err := strconv.ParseInt(param, 10, 64)
-	if err != nil {
-		panic(fmt.Sprintf("invalid min length string %s", param))
+	return minLength(utf8.RuneCountInString, "", MinStringLengthIdentifier)(param)
+}
+
+func maxStringLength(param string) Validator {
+	return maxLength(utf8.RuneCountInString, "", MaxStringLengthIdentifier)(param)
+}
+
+func minStringBytes(param string) Validator {
+	return minLength(byteCount, " bytes", MinStringBytesIdentifier)(param)
+}
+
+func maxStringBytes(param string) Validator {
+	return maxLength(byteCount, " bytes", MaxStringBytesIdentifier)(param)
+}
+
+func minStringGraphemes(param string) Validator {
+	return minLength(graphemeCount, " characters", MinStringGraphemesIdentifier)(param)
+}
+
+func maxStringGraphemes(param string) Validator {
+	return maxLength(graphemeCount, " characters", MaxStringGraphemesIdentifier)(param)
+}
+
+func containsString(param string) Validator {
+	if len(param) == 0 {
+		panic("string contains must have a parameter telling what contains")
 	}
 	return func(ctx ValidationContext) (bool, error) {
 		value := ctx.FieldValue.String()
-		if len(value) < int(minv) {
+		if !strings.Contains(value, param) {
 			return false, NewValidationErrorMeta(
 				ctx,
-				fmt.Sprintf("the field required minimum length of %d", minv),
-				MinStringLengthIdentifier,
+				fmt.Sprintf("the field must contain substring %s", param),
+				ContainsStringIdentifier,
 				param)
 		}
 		return true, nil
 	}
 }
 
-func maxStringLength(param string) Validator {
-	maxv, err := strconv.ParseInt(param, 10, 64)
-	if err != nil {
-		panic(fmt.Sprintf("invalid max length string %s", param))
+func oneOfString(params []string) Validator {
+	if len(params) == 0 {
+		panic("string oneof must have at least one parameter")
 	}
+	param := strings.Join(params, string(paramSeparator))
 	return func(ctx ValidationContext) (bool, error) {
 		value := ctx.FieldValue.String()
-		if len(value) > int(maxv) {
+		if !slices.Contains(params, value) {
 			return false, NewValidationErrorMeta(
 				ctx,
-				fmt.Sprintf("the field required maximum length of %d", maxv),
-				MaxStringLengthIdentifier,
+				fmt.Sprintf("the field must be one of %s", strings.Join(params, ", ")),
+				OneOfStringIdentifier,
 				param)
 		}
 		return true, nil
 	}
 }
 
-func containsString(param string) Validator {
-	if len(param) == 0 {
-		panic("string contains must have a parameter telling what contains")
+func regexString(param string) Validator {
+	re, err := regexp.Compile(param)
+	if err != nil {
+		panic(fmt.Sprintf("invalid regex %s: %s", param, err))
 	}
 	return func(ctx ValidationContext) (bool, error) {
 		value := ctx.FieldValue.String()
-		if !strings.Contains(value, param) {
+		if !re.MatchString(value) {
 			return false, NewValidationErrorMeta(
 				ctx,
-				fmt.Sprintf("the field must contain substring %s", param),
-				ContainsStringIdentifier,
+				fmt.Sprintf("the field must match the regular expression %s", param),
+				RegexStringIdentifier,
 				param)
 		}
 		return true, nil
 	}
 }
+
+// charClass checks that every rune of the string is in a class, so empty
+// strings are always valid.
+func charClass(in func(rune) bool, msg string, identifier ValidatorIdentifier) ValidatorConstructor {
+	return func(_ string) Validator {
+		return func(ctx ValidationContext) (bool, error) {
+			for _, c := range ctx.FieldValue.String() {
+				if !in(c) {
+					return false, NewValidationError(ctx, msg, identifier)
+				}
+			}
+			return true, nil
+		}
+	}
+}
+
+func isAlpha(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsMark(c)
+}
+
+func alphaString(param string) Validator {
+	return charClass(
+		isAlpha,
+		"the field must only contain letters",
+		AlphaStringIdentifier)(param)
+}
+
+func alphanumString(param string) Validator {
+	return charClass(
+		func(c rune) bool { return isAlpha(c) || unicode.IsDigit(c) },
+		"the field must only contain letters and digits",
+		AlphanumStringIdentifier)(param)
+}
+
+func numericString(param string) Validator {
+	return charClass(
+		unicode.IsDigit,
+		"the field must only contain digits",
+		NumericStringIdentifier)(param)
+}
+
+func asciiString(param string) Validator {
+	return charClass(
+		func(c rune) bool { return c <= unicode.MaxASCII },
+		"the field must only contain ASCII characters",
+		ASCIIStringIdentifier)(param)
+}
+
+func printASCIIString(param string) Validator {
+	return charClass(
+		func(c rune) bool { return c >= ' ' && c <= '~' },
+		"the field must only contain printable ASCII characters",
+		PrintASCIIStringIdentifier)(param)
+}
+
+func lowercaseString(param string) Validator {
+	return charClass(
+		func(c rune) bool { return !unicode.IsUpper(c) && !unicode.IsTitle(c) },
+		"the field must not contain uppercase letters",
+		LowercaseStringIdentifier)(param)
+}
+
+func uppercaseString(param string) Validator {
+	return charClass(
+		func(c rune) bool { return !unicode.IsLower(c) && !unicode.IsTitle(c) },
+		"the field must not contain lowercase letters",
+		UppercaseStringIdentifier)(param)
+}
+
+// substring builds validators that compare the string with their param.
+func substring(name string, matches func(str, param string) bool, msg string, identifier ValidatorIdentifier) ValidatorConstructor {
+	return func(param string) Validator {
+		if len(param) == 0 {
+			panic(fmt.Sprintf("string %s must have a parameter", name))
+		}
+		return func(ctx ValidationContext) (bool, error) {
+			if !matches(ctx.FieldValue.String(), param) {
+				return false, NewValidationErrorMeta(
+					ctx,
+					fmt.Sprintf(msg, param),
+					identifier,
+					param)
+			}
+			return true, nil
+		}
+	}
+}
+
+func startsWithString(param string) Validator {
+	return substring(
+		"startswith",
+		strings.HasPrefix,
+		"the field must start with %s",
+		StartsWithStringIdentifier)(param)
+}
+
+func endsWithString(param string) Validator {
+	return substring(
+		"endswith",
+		strings.HasSuffix,
+		"the field must end with %s",
+		EndsWithStringIdentifier)(param)
+}
+
+func excludesString(param string) Validator {
+	return substring(
+		"excludes",
+		func(str, param string) bool { return !strings.Contains(str, param) },
+		"the field must not contain substring %s",
+		ExcludesStringIdentifier)(param)
+}
+
+func excludesAllString(param string) Validator {
+	return substring(
+		"excludesall",
+		func(str, param string) bool { return !strings.ContainsAny(str, param) },
+		"the field must not contain any of the characters %s",
+		ExcludesAllStringIdentifier)(param)
+}
+
+func containsAnyString(param string) Validator {
+	return substring(
+		"containsany",
+		strings.ContainsAny,
+		"the field must contain any of the characters %s",
+		ContainsAnyStringIdentifier)(param)
+}
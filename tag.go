@@ -0,0 +1,252 @@
+package valtruc
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	tagSeparator   = ','
+	paramSeparator = '|'
+	paramStart     = '='
+	groupStart     = '@'
+	quote          = '\''
+	escape         = '\\'
+)
+
+type valTag struct {
+	structType   reflect.Type
+	field        reflect.StructField
+	original     string
+	name         string
+	params       []string
+	negated      bool
+	alternatives []valTag
+	alias        string
+	groups       []string
+}
+
+// parseValtrucTag reads a tag like "required, min=3, contains='a,b', between=1|10".
+// Rules are separated by commas and parameters by pipes. Single quotes keep
+// their content verbatim and a backslash escapes the next character.
+// A rule can also be a list of alternatives separated by pipes
+// ("hexcolor|rgb"), and any rule can be negated with a leading "!".
+// Rules ending with "@group" only run when validating that group.
+func parseValtrucTag(tag string, field reflect.StructField, structType reflect.Type) []valTag {
+	rules := splitUnquoted(tag, tagSeparator)
+	result := make([]valTag, 0, len(rules))
+	for _, rule := range rules {
+		rule, groups := splitGroups(strings.TrimSpace(rule))
+		if len(rule) == 0 {
+			continue
+		}
+
+		alternatives := splitAlternatives(rule)
+		if len(alternatives) == 1 {
+			parsed := parseRule(rule, field, structType)
+			parsed.groups = groups
+			result = append(result, parsed)
+			continue
+		}
+
+		parsed := valTag{
+			structType: structType,
+			field:      field,
+			original:   rule,
+			groups:     groups,
+		}
+		for _, alternative := range alternatives {
+			parsed.alternatives = append(parsed.alternatives, parseRule(alternative, field, structType))
+		}
+		result = append(result, parsed)
+	}
+
+	return result
+}
+
+// splitGroups removes the "@group" suffixes of a rule. A group must come
+// after a complete rule: a name ("required@create") or a quoted param
+// ("min='3'@update"). Any other "@" is part of a param, so "contains=a@b"
+// and "endswith=@gmail" are not grouped.
+func splitGroups(rule string) (string, []string) {
+	groups := []string{}
+	for {
+		index := -1
+		scanUnquoted(rule, groupStart, func(i int) bool {
+			index = i
+			return true
+		})
+		if index == -1 || !isGroupName(rule[index+1:]) || !isCompleteRule(rule[:index]) {
+			break
+		}
+		groups = append([]string{rule[index+1:]}, groups...)
+		rule = strings.TrimSpace(rule[:index])
+	}
+	return rule, groups
+}
+
+// isCompleteRule tells if a rule has no params or ends with a closing quote.
+func isCompleteRule(rule string) bool {
+	if indexUnquoted(rule, paramStart) == -1 {
+		return true
+	}
+	rule = strings.TrimRightFunc(rule, unicode.IsSpace)
+	if !strings.HasSuffix(rule, string(quote)) {
+		return false
+	}
+	escapes := len(rule) - 1 - len(strings.TrimRight(rule[:len(rule)-1], string(escape)))
+	return escapes%2 == 0
+}
+
+func isGroupName(name string) bool {
+	if len(name) == 0 {
+		return false
+	}
+	for _, c := range name {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_' && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// splitAlternatives splits a rule on the pipes that are not parameter
+// separators. Once a rule has parameters, the following pieces are
+// parameters too unless they have their own "=", so "between=1|10|len=0"
+// are two alternatives and "email|between=1|10" another two.
+func splitAlternatives(rule string) []string {
+	alternatives := []string{}
+	hasParams := false
+	for _, piece := range splitUnquoted(rule, paramSeparator) {
+		pieceHasParams := indexUnquoted(piece, paramStart) != -1
+		if hasParams && !pieceHasParams {
+			last := len(alternatives) - 1
+			alternatives[last] += string(paramSeparator) + piece
+			continue
+		}
+		alternatives = append(alternatives, strings.TrimSpace(piece))
+		hasParams = pieceHasParams
+	}
+	return alternatives
+}
+
+func parseRule(rule string, field reflect.StructField, structType reflect.Type) valTag {
+	negated := strings.HasPrefix(rule, "!")
+	if negated {
+		rule = strings.TrimSpace(rule[1:])
+	}
+
+	var name string
+	var params []string
+	startParamsIndex := indexUnquoted(rule, paramStart)
+	if startParamsIndex != -1 {
+		name = strings.TrimSpace(rule[0:startParamsIndex])
+		for _, param := range splitUnquoted(rule[startParamsIndex+1:], paramSeparator) {
+			params = append(params, unquoteParam(param))
+		}
+	} else {
+		name = rule
+	}
+
+	return valTag{
+		structType: structType,
+		field:      field,
+		original:   rule,
+		name:       name,
+		params:     params,
+		negated:    negated,
+	}
+}
+
+func scanUnquoted(str string, sep rune, onSeparator func(i int) bool) {
+	quoted := false
+	escaped := false
+	for i, c := range str {
+		switch {
+		case escaped:
+			escaped = false
+		case c == escape:
+			escaped = true
+		case c == quote:
+			quoted = !quoted
+		case c == sep && !quoted:
+			if !onSeparator(i) {
+				return
+			}
+		}
+	}
+	if quoted {
+		panic(fmt.Sprintf("valtruc: unterminated quote in tag %s", str))
+	}
+	if escaped {
+		panic(fmt.Sprintf("valtruc: unterminated escape in tag %s", str))
+	}
+}
+
+func splitUnquoted(str string, sep rune) []string {
+	parts := []string{}
+	start := 0
+	scanUnquoted(str, sep, func(i int) bool {
+		parts = append(parts, str[start:i])
+		start = i + 1
+		return true
+	})
+	return append(parts, str[start:])
+}
+
+func indexUnquoted(str string, sep rune) int {
+	index := -1
+	scanUnquoted(str, sep, func(i int) bool {
+		index = i
+		return false
+	})
+	return index
+}
+
+func unquoteParam(raw string) string {
+	result := []rune{}
+	literal := []bool{}
+	quoted := false
+	escaped := false
+	for _, c := range raw {
+		switch {
+		case escaped:
+			result = append(result, c)
+			literal = append(literal, true)
+			escaped = false
+		case c == escape:
+			escaped = true
+		case c == quote:
+			quoted = !quoted
+		default:
+			result = append(result, c)
+			literal = append(literal, quoted)
+		}
+	}
+
+	start := 0
+	for start < len(result) && !literal[start] && unicode.IsSpace(result[start]) {
+		start++
+	}
+	end := len(result)
+	for end > start && !literal[end-1] && unicode.IsSpace(result[end-1]) {
+		end--
+	}
+	return string(result[start:end])
+}
+
+func escapeParam(param string) string {
+	var b strings.Builder
+	for i, c := range param {
+		special := strings.ContainsRune(",|='@\\", c) ||
+			(unicode.IsSpace(c) && (i == 0 || i+utf8.RuneLen(c) == len(param)))
+		if special {
+			b.WriteRune(escape)
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
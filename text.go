@@ -0,0 +1,73 @@
+package valtruc
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+const (
+	TextMarshalIdentifier ValidatorIdentifier = "textMarshalIdentifier"
+)
+
+var (
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	stringType        = reflect.TypeFor[string]()
+)
+
+// WithTextMarshalers validates fields implementing encoding.TextMarshaler
+// with the string validators, using the text they marshal to.
+func WithTextMarshalers() Option {
+	return func(vt *Valtruc) {
+		vt.textMarshalers = true
+	}
+}
+
+func (vt Valtruc) validatesAsText(t reflect.Type) bool {
+	if !vt.textMarshalers || t.Kind() == reflect.String {
+		return false
+	}
+	return t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType)
+}
+
+func marshalText(value reflect.Value) ([]byte, error) {
+	if !value.Type().Implements(textMarshalerType) {
+		ptr := reflect.New(value.Type())
+		ptr.Elem().Set(value)
+		value = ptr
+	}
+	return value.Interface().(encoding.TextMarshaler).MarshalText()
+}
+
+func textValidatorWrapper(inner Validator) Validator {
+	return func(ctx ValidationContext) (bool, error) {
+		text, err := marshalText(ctx.FieldValue)
+		if err != nil {
+			return false, NewValidationError(
+				ctx,
+				fmt.Sprintf("the field cannot be converted to text: %s", err),
+				TextMarshalIdentifier)
+		}
+		ctx.FieldValue = reflect.ValueOf(string(text))
+		return inner(ctx)
+	}
+}
+
+// checksValue tells if a rule (and every alternative of it) runs on the
+// value instead of its text: the rules the type has its own validators
+// for, and required, so a zero value is missing even if it has a text.
+func (vt Valtruc) checksValue(t reflect.Type, tag valTag) bool {
+	if len(tag.alternatives) == 0 {
+		if _, ok := vt.typeValidators[t][tag.name]; ok {
+			return true
+		}
+		_, ok := vt.validators[t.Kind()][tag.name]
+		return ok && tag.name == "required"
+	}
+	for _, alternative := range tag.alternatives {
+		if !vt.checksValue(t, alternative) {
+			return false
+		}
+	}
+	return true
+}
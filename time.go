@@ -0,0 +1,195 @@
+package valtruc
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	BeforeTimeIdentifier  ValidatorIdentifier = "beforeTimeIdentifier"
+	AfterTimeIdentifier   ValidatorIdentifier = "afterTimeIdentifier"
+	PastTimeIdentifier    ValidatorIdentifier = "pastTimeIdentifier"
+	FutureTimeIdentifier  ValidatorIdentifier = "futureTimeIdentifier"
+	WithinTimeIdentifier  ValidatorIdentifier = "withinTimeIdentifier"
+	WeekdayTimeIdentifier ValidatorIdentifier = "weekdayTimeIdentifier"
+	MinDurationIdentifier ValidatorIdentifier = "minDurationIdentifier"
+	MaxDurationIdentifier ValidatorIdentifier = "maxDurationIdentifier"
+)
+
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	time.DateOnly,
+}
+
+func parseTime(param string) time.Time {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, param); err == nil {
+			return t
+		}
+	}
+	panic(fmt.Sprintf("invalid time %s, use a layout like %s", param, strings.Join(timeLayouts, " or ")))
+}
+
+func timeOf(ctx ValidationContext) time.Time {
+	return ctx.FieldValue.Interface().(time.Time)
+}
+
+func requireTime(_ string) Validator {
+	return func(ctx ValidationContext) (bool, error) {
+		if timeOf(ctx).IsZero() {
+			return false, NewValidationError(
+				ctx,
+				"the field is required",
+				RequiredIdentifier)
+		}
+		return true, nil
+	}
+}
+
+func beforeTime(param string) Validator {
+	limit := parseTime(param)
+	return func(ctx ValidationContext) (bool, error) {
+		if !timeOf(ctx).Before(limit) {
+			return false, NewValidationErrorMeta(
+				ctx,
+				fmt.Sprintf("time must be before %s", limit.Format(time.RFC3339)),
+				BeforeTimeIdentifier,
+				param)
+		}
+		return true, nil
+	}
+}
+
+func afterTime(param string) Validator {
+	limit := parseTime(param)
+	return func(ctx ValidationContext) (bool, error) {
+		if !timeOf(ctx).After(limit) {
+			return false, NewValidationErrorMeta(
+				ctx,
+				fmt.Sprintf("time must be after %s", limit.Format(time.RFC3339)),
+				AfterTimeIdentifier,
+				param)
+		}
+		return true, nil
+	}
+}
+
+func pastTime(_ string) Validator {
+	return func(ctx ValidationContext) (bool, error) {
+		if !timeOf(ctx).Before(time.Now()) {
+			return false, NewValidationError(
+				ctx,
+				"time must be in the past",
+				PastTimeIdentifier)
+		}
+		return true, nil
+	}
+}
+
+func futureTime(_ string) Validator {
+	return func(ctx ValidationContext) (bool, error) {
+		if !timeOf(ctx).After(time.Now()) {
+			return false, NewValidationError(
+				ctx,
+				"time must be in the future",
+				FutureTimeIdentifier)
+		}
+		return true, nil
+	}
+}
+
+func withinTime(param string) Validator {
+	window, err := time.ParseDuration(param)
+	if err != nil {
+		panic(fmt.Sprintf("invalid within duration %s", param))
+	}
+	return func(ctx ValidationContext) (bool, error) {
+		distance := time.Since(timeOf(ctx)).Abs()
+		if distance > window {
+			return false, NewValidationErrorMeta(
+				ctx,
+				fmt.Sprintf("time must be within %s from now", window),
+				WithinTimeIdentifier,
+				param)
+		}
+		return true, nil
+	}
+}
+
+func weekdayTime(params []string) Validator {
+	allowed := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	if len(params) > 0 {
+		allowed = make([]time.Weekday, len(params))
+		for i, param := range params {
+			allowed[i] = parseWeekday(param)
+		}
+	}
+	names := make([]string, len(allowed))
+	for i, day := range allowed {
+		names[i] = day.String()
+	}
+	return func(ctx ValidationContext) (bool, error) {
+		if !slices.Contains(allowed, timeOf(ctx).Weekday()) {
+			return false, NewValidationErrorMeta(
+				ctx,
+				fmt.Sprintf("time must be on %s", strings.Join(names, ", ")),
+				WeekdayTimeIdentifier,
+				strings.Join(params, string(paramSeparator)))
+		}
+		return true, nil
+	}
+}
+
+func parseWeekday(param string) time.Weekday {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := day.String()
+		if strings.EqualFold(param, name) || strings.EqualFold(param, name[:3]) {
+			return day
+		}
+	}
+	panic(fmt.Sprintf("invalid weekday %s", param))
+}
+
+func durationOf(ctx ValidationContext) time.Duration {
+	return time.Duration(ctx.FieldValue.Int())
+}
+
+func parseDuration(param string) time.Duration {
+	d, err := time.ParseDuration(param)
+	if err != nil {
+		panic(fmt.Sprintf("invalid duration %s", param))
+	}
+	return d
+}
+
+func minDuration(param string) Validator {
+	minv := parseDuration(param)
+	return func(ctx ValidationContext) (bool, error) {
+		if durationOf(ctx) < minv {
+			return false, NewValidationErrorMeta(
+				ctx,
+				fmt.Sprintf("duration must be at least %s", minv),
+				MinDurationIdentifier,
+				param)
+		}
+		return true, nil
+	}
+}
+
+func maxDuration(param string) Validator {
+	maxv := parseDuration(param)
+	return func(ctx ValidationContext) (bool, error) {
+		if durationOf(ctx) > maxv {
+			return false, NewValidationErrorMeta(
+				ctx,
+				fmt.Sprintf("duration must be at most %s", maxv),
+				MaxDurationIdentifier,
+				param)
+		}
+		return true, nil
+	}
+}
@@ -0,0 +1,28 @@
+package valtruc
+
+import "reflect"
+
+// TypedValidator holds the compiled rules of T, so validating a value
+// does not need to look them up or check its kind again.
+type TypedValidator[T any] struct {
+	vt         *Valtruc
+	structType reflect.Type
+	compiled   map[string]compiledValidation
+}
+
+func Typed[T any](vt *Valtruc) TypedValidator[T] {
+	t := reflect.TypeFor[T]()
+	return TypedValidator[T]{
+		vt:         vt,
+		structType: t,
+		compiled:   vt.compiledFor(t),
+	}
+}
+
+func (tv TypedValidator[T]) Validate(target T) []error {
+	return tv.vt.validate(tv.structType, reflect.ValueOf(&target).Elem(), tv.compiled, tv.vt.newRun())
+}
+
+func ValidateT[T any](vt *Valtruc, target T) []error {
+	return Typed[T](vt).Validate(target)
+}
@@ -0,0 +1,118 @@
+package valtruc
+
+import (
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	UUIDIdentifier  ValidatorIdentifier = "uuidIdentifier"
+	UUID4Identifier ValidatorIdentifier = "uuid4Identifier"
+	UUID7Identifier ValidatorIdentifier = "uuid7Identifier"
+	ULIDIdentifier  ValidatorIdentifier = "ulidIdentifier"
+)
+
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+func uuidValidator(version byte, identifier ValidatorIdentifier) ValidatorConstructor {
+	msg := "the field must be a UUID"
+	if version != 0 {
+		msg = fmt.Sprintf("the field must be a version %d UUID", version)
+	}
+	return func(_ string) Validator {
+		return func(ctx ValidationContext) (bool, error) {
+			id, ok := uuidOf(ctx.FieldValue)
+			if !ok || (version != 0 && !hasUUIDVersion(id, version)) {
+				return false, NewValidationError(ctx, msg, identifier)
+			}
+			return true, nil
+		}
+	}
+}
+
+func uuid(param string) Validator {
+	return uuidValidator(0, UUIDIdentifier)(param)
+}
+
+func uuid4(param string) Validator {
+	return uuidValidator(4, UUID4Identifier)(param)
+}
+
+func uuid7(param string) Validator {
+	return uuidValidator(7, UUID7Identifier)(param)
+}
+
+func hasUUIDVersion(id []byte, version byte) bool {
+	return id[6]>>4 == version && id[8]&0xc0 == 0x80
+}
+
+// uuidOf gives the bytes of a UUID in canonical form
+// (xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx) or in a [16]byte array.
+func uuidOf(value reflect.Value) ([]byte, bool) {
+	if value.Kind() == reflect.Array {
+		return bytesOfArray(value), true
+	}
+
+	str := value.String()
+	if len(str) != 36 {
+		return nil, false
+	}
+	for i, c := range str {
+		isDash := i == 8 || i == 13 || i == 18 || i == 23
+		if isDash != (c == '-') || (!isDash && !isHexDigit(c)) {
+			return nil, false
+		}
+	}
+	id, err := hex.DecodeString(strings.ReplaceAll(str, "-", ""))
+	if err != nil || len(id) != 16 {
+		return nil, false
+	}
+	return id, true
+}
+
+func isHexDigit(c rune) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func isIdentifierArray(t reflect.Type) bool {
+	return t.Len() == 16 && t.Elem().Kind() == reflect.Uint8
+}
+
+func bytesOfArray(value reflect.Value) []byte {
+	id := make([]byte, value.Len())
+	for i := range id {
+		id[i] = byte(value.Index(i).Uint())
+	}
+	return id
+}
+
+func ulid(_ string) Validator {
+	return func(ctx ValidationContext) (bool, error) {
+		if ctx.FieldValue.Kind() == reflect.Array {
+			return true, nil
+		}
+		if !isULID(ctx.FieldValue.String()) {
+			return false, NewValidationError(
+				ctx,
+				"the field must be a ULID",
+				ULIDIdentifier)
+		}
+		return true, nil
+	}
+}
+
+// isULID checks a 26 characters Crockford base32 string. The first
+// character can be at most 7, as a ULID has 128 bits.
+func isULID(str string) bool {
+	if len(str) != 26 || str[0] > '7' {
+		return false
+	}
+	for _, c := range strings.ToUpper(str) {
+		if !strings.ContainsRune(crockfordAlphabet, c) {
+			return false
+		}
+	}
+	return true
+}
@@ -1,45 +1,124 @@
 package valtruc
 
-import "reflect"
+import (
+	"maps"
+	"reflect"
+	"time"
+)
 
-func createValidators() map[reflect.Kind]map[string]ValidatorConstructor {
+func createValidators() map[reflect.Kind]map[string]ParamsValidatorConstructor {
 
-	var intValidators = map[string]ValidatorConstructor{
-		"required": require,
-		"min":      minInt64,
-		"max":      maxInt64,
+	var intValidators = map[string]ParamsValidatorConstructor{
+		"required": withParam(require),
+		"min":      withParam(minInt64),
+		"max":      withParam(maxInt64),
+		"between":  betweenInt64,
+		"oneof":    oneOfInt64,
+		"eqfield":  withParam(equalField),
+		"nefield":  withParam(notEqualField),
 	}
 
-	var stringValidators = map[string]ValidatorConstructor{
-		"required": require,
-		"min":      minStringLength,
-		"max":      maxStringLength,
-		"contains": containsString,
+	var stringValidators = map[string]ParamsValidatorConstructor{
+		"required":       withParam(require),
+		"min":            withParam(minStringLength),
+		"max":            withParam(maxStringLength),
+		"minbytes":       withParam(minStringBytes),
+		"maxbytes":       withParam(maxStringBytes),
+		"mingraphemes":   withParam(minStringGraphemes),
+		"maxgraphemes":   withParam(maxStringGraphemes),
+		"contains":       withParam(containsString),
+		"alpha":          withParam(alphaString),
+		"alphanum":       withParam(alphanumString),
+		"numeric":        withParam(numericString),
+		"ascii":          withParam(asciiString),
+		"printascii":     withParam(printASCIIString),
+		"lowercase":      withParam(lowercaseString),
+		"uppercase":      withParam(uppercaseString),
+		"startswith":     withParam(startsWithString),
+		"endswith":       withParam(endsWithString),
+		"excludes":       withParam(excludesString),
+		"excludesall":    withParam(excludesAllString),
+		"containsany":    withParam(containsAnyString),
+		"oneof":          oneOfString,
+		"regex":          withParam(regexString),
+		"uuid":           withParam(uuid),
+		"uuid4":          withParam(uuid4),
+		"uuid7":          withParam(uuid7),
+		"ulid":           withParam(ulid),
+		"ip":             withParam(ip),
+		"ipv4":           withParam(ipv4),
+		"ipv6":           withParam(ipv6),
+		"cidr":           withParam(cidr),
+		"mac":            withParam(mac),
+		"hostport":       withParam(hostPort),
+		"port":           withParam(port),
+		"ip_in":          ipIn,
+		"base64":         withParam(base64Validator),
+		"base64url":      withParam(base64URLValidator),
+		"hex":            withParam(hexValidator),
+		"json":           withParam(jsonValidator),
+		"utf8":           withParam(utf8Validator),
+		"jwt":            withParam(jwtValidator),
+		"creditcard":     withParam(creditCard),
+		"iban":           withParam(iban),
+		"bic":            withParam(bic),
+		"isin":           withParam(isin),
+		"iso3166_alpha2": iso3166Alpha2,
+		"iso3166_alpha3": iso3166Alpha3,
+		"iso4217":        iso4217,
+		"bcp47":          bcp47,
+		"country":        iso3166Alpha2,
+		"currency":       iso4217,
+		"eqfield":        withParam(equalField),
+		"nefield":        withParam(notEqualField),
 	}
 
-	var floatValidators = map[string]ValidatorConstructor{
-		"required": require,
-		"min":      minFloat64,
-		"max":      maxFloat64,
+	var floatValidators = map[string]ParamsValidatorConstructor{
+		"required": withParam(require),
+		"min":      withParam(minFloat64),
+		"max":      withParam(maxFloat64),
+		"between":  betweenFloat64,
+		"eqfield":  withParam(equalField),
+		"nefield":  withParam(notEqualField),
 	}
 
-	var boolValidators = map[string]ValidatorConstructor{
-		"required":    require,
-		"mustBeTrue":  mustBeTrue,
-		"mustBeFalse": mustBeFalse,
+	var boolValidators = map[string]ParamsValidatorConstructor{
+		"required":    withParam(require),
+		"mustBeTrue":  withParam(mustBeTrue),
+		"mustBeFalse": withParam(mustBeFalse),
+		"eqfield":     withParam(equalField),
+		"nefield":     withParam(notEqualField),
 	}
 
-	var structValidators = map[string]ValidatorConstructor{
-		"required": require,
+	var structValidators = map[string]ParamsValidatorConstructor{
+		"required": withParam(require),
+		"eqfield":  withParam(equalField),
+		"nefield":  withParam(notEqualField),
 	}
 
-	var sliceValidators = map[string]ValidatorConstructor{
-		"required": requiredSlice,
-		"max":      maxSliceLength,
-		"min":      minSliceLength,
+	var sliceValidators = map[string]ParamsValidatorConstructor{
+		"required":  withParam(requiredSlice),
+		"max":       withParam(maxSliceLength),
+		"min":       withParam(minSliceLength),
+		"base64":    withParam(base64Validator),
+		"base64url": withParam(base64URLValidator),
+		"hex":       withParam(hexValidator),
+		"json":      withParam(jsonValidator),
+		"utf8":      withParam(utf8Validator),
+		"jwt":       withParam(jwtValidator),
+		"eqfield":   withParam(equalField),
+		"nefield":   withParam(notEqualField),
 	}
 
-	return map[reflect.Kind]map[string]ValidatorConstructor{
+	var arrayValidators = map[string]ParamsValidatorConstructor{
+		"required": withParam(require),
+		"uuid":     withParam(uuid),
+		"uuid4":    withParam(uuid4),
+		"uuid7":    withParam(uuid7),
+		"ulid":     withParam(ulid),
+	}
+
+	validators := map[reflect.Kind]map[string]ParamsValidatorConstructor{
 		reflect.String:  stringValidators,
 		reflect.Int:     intValidators,
 		reflect.Int16:   intValidators,
@@ -54,5 +133,78 @@ func createValidators() map[reflect.Kind]map[string]ValidatorConstructor {
 		reflect.Bool:    boolValidators,
 		reflect.Struct:  structValidators,
 		reflect.Slice:   sliceValidators,
+		reflect.Array:   arrayValidators,
+	}
+
+	// Each kind gets its own map, so adding a validator for reflect.Int
+	// does not add it to reflect.Int64 too.
+	for kind, forKind := range validators {
+		validators[kind] = maps.Clone(forKind)
+	}
+	return validators
+}
+
+// createTypeValidators gives validators for concrete types. They are
+// looked up before the ones for the kind of the type.
+func createTypeValidators() map[reflect.Type]map[string]ParamsValidatorConstructor {
+
+	var timeValidators = map[string]ParamsValidatorConstructor{
+		"required": withParam(requireTime),
+		"before":   withParam(beforeTime),
+		"after":    withParam(afterTime),
+		"past":     withParam(pastTime),
+		"future":   withParam(futureTime),
+		"within":   withParam(withinTime),
+		"weekday":  weekdayTime,
+	}
+
+	var durationValidators = map[string]ParamsValidatorConstructor{
+		"min": withParam(minDuration),
+		"max": withParam(maxDuration),
+	}
+
+	var addrValidators = map[string]ParamsValidatorConstructor{
+		"required": withParam(require),
+		"ip":       withParam(ip),
+		"ipv4":     withParam(ipv4),
+		"ipv6":     withParam(ipv6),
+		"ip_in":    ipIn,
+	}
+
+	var prefixValidators = map[string]ParamsValidatorConstructor{
+		"required": withParam(require),
+		"cidr":     withParam(cidr),
+		"ipv4":     withParam(ipv4),
+		"ipv6":     withParam(ipv6),
+		"ip_in":    ipIn,
+	}
+
+	return map[reflect.Type]map[string]ParamsValidatorConstructor{
+		reflect.TypeFor[time.Time]():     timeValidators,
+		reflect.TypeFor[time.Duration](): durationValidators,
+		addrType:                         addrValidators,
+		prefixType:                       prefixValidators,
+	}
+}
+
+// createTypeChecks gives the types accepted by validators that only work
+// with some types of a kind, like uuid with [16]byte arrays or json with
+// []byte. Tags using them with other types panic when compiled.
+func createTypeChecks() map[reflect.Kind]map[string]func(reflect.Type) bool {
+	return map[reflect.Kind]map[string]func(reflect.Type) bool{
+		reflect.Array: {
+			"uuid":  isIdentifierArray,
+			"uuid4": isIdentifierArray,
+			"uuid7": isIdentifierArray,
+			"ulid":  isIdentifierArray,
+		},
+		reflect.Slice: {
+			"base64":    isByteSlice,
+			"base64url": isByteSlice,
+			"hex":       isByteSlice,
+			"json":      isByteSlice,
+			"utf8":      isByteSlice,
+			"jwt":       isByteSlice,
+		},
 	}
 }
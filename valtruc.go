@@ -1,10 +1,13 @@
 package valtruc
 
 import (
+	"context"
 	"fmt"
+	"maps"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync/atomic"
 )
 
 type ValidatorIdentifier string
@@ -14,6 +17,9 @@ type ValidationError struct {
 	msg        string
 	identifier ValidatorIdentifier
 	param      string
+	causes     []error
+	alias      string
+	metadata   map[string]any
 }
 
 func (err ValidationError) Path() []string {
@@ -52,6 +58,9 @@ func (verr ValidationError) GetStructName() string {
 }
 
 func (verr ValidationError) GetFieldName() string {
+	if len(verr.ctx.FieldName) > 0 {
+		return verr.ctx.FieldName
+	}
 	return verr.ctx.Field.Name
 }
 
@@ -88,6 +97,30 @@ func (verr ValidationError) GetParam() string {
 	return verr.param
 }
 
+func (verr ValidationError) GetAlias() string {
+	return verr.alias
+}
+
+// WithMetadata returns a copy of the error with extra information about
+// the failure, like the offset of the first invalid byte.
+func (verr ValidationError) WithMetadata(key string, value any) ValidationError {
+	metadata := maps.Clone(verr.metadata)
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+	metadata[key] = value
+	verr.metadata = metadata
+	return verr
+}
+
+func (verr ValidationError) GetMetadata() map[string]any {
+	return verr.metadata
+}
+
+func (verr ValidationError) Unwrap() []error {
+	return verr.causes
+}
+
 func (verr ValidationError) Error() string {
 	return fmt.Sprintf(
 		"Validation error on struct '%s', field '%s' (%s) with value '%s': [%s] %s",
@@ -128,47 +161,179 @@ func (verr ValidationError) Format(str string) string {
 }
 
 type ValidationContext struct {
-	StructType reflect.Type
-	Field      reflect.StructField
-	FieldIndex int
-	FieldValue reflect.Value
-	Path       []string
+	Context     context.Context
+	StructType  reflect.Type
+	StructValue reflect.Value
+	Field       reflect.StructField
+	FieldName   string
+	FieldIndex  int
+	FieldValue  reflect.Value
+	Path        []string
 }
 
 type Validator func(ctx ValidationContext) (bool, error)
 type ValidatorConstructor func(param string) Validator
+type ParamsValidatorConstructor func(params []string) Validator
+
+func withParam(constructor ValidatorConstructor) ParamsValidatorConstructor {
+	return func(params []string) Validator {
+		return constructor(strings.Join(params, string(paramSeparator)))
+	}
+}
 
 type compiledValidation struct {
-	validators []Validator
+	rules      []compiledRule
+	omitEmpty  bool
+	omitNil    bool
+	bail       bool
+	concurrent bool
+}
+
+func (cValidation compiledValidation) skips(value reflect.Value) bool {
+	if cValidation.omitNil && value.IsNil() {
+		return true
+	}
+	return cValidation.omitEmpty && isEmpty(value)
 }
 
-func (cValidation compiledValidation) validate(ctx ValidationContext) (bool, []error) {
+func (cValidation compiledValidation) validate(ctx ValidationContext, run *validationRun) bool {
+	if cValidation.skips(ctx.FieldValue) {
+		return true
+	}
 	result := true
-	errors := []error{}
-	for _, validator := range cValidation.validators {
-		ok, err := validator(ctx)
+	for _, rule := range cValidation.rules {
+		if !rule.runsIn(run.groups) {
+			continue
+		}
+		if run.done() {
+			return false
+		}
+		ok, err := rule.validator(ctx)
+		if run.ctx.Err() != nil {
+			return false
+		}
 		if !ok {
-			errors = append(errors, err)
+			run.add(err)
+			if cValidation.bail {
+				return false
+			}
 		}
 		result = result && ok
 	}
-	return result, errors
+	return result
+}
+
+type validationRun struct {
+	ctx       context.Context
+	errs      []error
+	maxErrors int
+	filter    *pathFilter
+	groups    []string
+	sem       chan struct{}
+	// failures counts every error of the validation, in any run.
+	failures *atomic.Int64
+	// count has the errors of this run and its children. parent, offset,
+	// siblings and index place the run in the result to know when to stop.
+	count    atomic.Int64
+	parent   *validationRun
+	offset   int
+	siblings []*validationRun
+	index    int
+}
+
+func (run *validationRun) add(err error) {
+	run.errs = append(run.errs, err)
+	run.failures.Add(1)
+	for r := run; r != nil; r = r.parent {
+		r.count.Add(1)
+	}
+}
+
+func (run *validationRun) done() bool {
+	if run.ctx.Err() != nil {
+		return true
+	}
+	if run.maxErrors == 0 || run.failures.Load() < int64(run.maxErrors) {
+		return false
+	}
+	return run.preceding()+run.count.Load() >= int64(run.maxErrors)
+}
+
+func (run *validationRun) selects(path []string, name string) (rules bool, recurse bool) {
+	if run.filter == nil {
+		return true, true
+	}
+	return run.filter.selects(strings.Join(append(path[:len(path):len(path)], name), "."))
 }
 
 type Valtruc struct {
-	compiled   map[reflect.Type]map[string]compiledValidation
-	validators map[reflect.Kind]map[string]ValidatorConstructor
+	compiled       map[reflect.Type]map[string]compiledValidation
+	validators     map[reflect.Kind]map[string]ParamsValidatorConstructor
+	typeValidators map[reflect.Type]map[string]ParamsValidatorConstructor
+	rules          map[reflect.Type]map[string]string
+	vars           map[varKey]compiledValidation
+	aliases        map[string]string
+	tagNames       []string
+	fieldName      FieldNameResolver
+	translator     Translator
+	strict         bool
+	maxErrors      int
+	parallelism    int
+	textMarshalers bool
+	unwrappers     map[reflect.Type]unwrapper
+	ctxNames       map[reflect.Kind]map[string]bool
+	typeChecks     map[reflect.Kind]map[string]func(reflect.Type) bool
 }
 
-func New() Valtruc {
-	return Valtruc{
-		compiled:   map[reflect.Type]map[string]compiledValidation{},
-		validators: createValidators(),
+func New(opts ...Option) Valtruc {
+	vt := Valtruc{
+		compiled:       map[reflect.Type]map[string]compiledValidation{},
+		validators:     createValidators(),
+		typeValidators: createTypeValidators(),
+		rules:          map[reflect.Type]map[string]string{},
+		vars:           map[varKey]compiledValidation{},
+		aliases:        map[string]string{},
+		tagNames:       []string{"valtruc"},
+		strict:         true,
+		ctxNames:       map[reflect.Kind]map[string]bool{},
+		typeChecks:     createTypeChecks(),
+		unwrappers:     map[reflect.Type]unwrapper{},
+	}
+	for _, opt := range opts {
+		opt(&vt)
 	}
+	return vt
 }
 
 func (vt *Valtruc) AddValidator(forKind reflect.Kind, tagName string, constructor ValidatorConstructor) {
-	vt.validators[forKind][tagName] = constructor
+	vt.AddParamsValidator(forKind, tagName, withParam(constructor))
+}
+
+// AddTypeValidator adds a validator for a concrete type, like netip.Addr.
+// Validators for a type are looked up before the ones for its kind, and
+// struct types with validators are validated as a whole, not field by field.
+func (vt *Valtruc) AddTypeValidator(forType reflect.Type, tagName string, constructor ValidatorConstructor) {
+	vt.AddTypeParamsValidator(forType, tagName, withParam(constructor))
+}
+
+func (vt *Valtruc) AddTypeParamsValidator(forType reflect.Type, tagName string, constructor ParamsValidatorConstructor) {
+	validators, ok := vt.typeValidators[forType]
+	if !ok {
+		validators = map[string]ParamsValidatorConstructor{}
+		vt.typeValidators[forType] = validators
+	}
+	validators[tagName] = constructor
+}
+
+func (vt *Valtruc) AddParamsValidator(forKind reflect.Kind, tagName string, constructor ParamsValidatorConstructor) {
+	validators, ok := vt.validators[forKind]
+	if !ok {
+		validators = map[string]ParamsValidatorConstructor{}
+		vt.validators[forKind] = validators
+	}
+	validators[tagName] = constructor
+	delete(vt.ctxNames[forKind], tagName)
+	delete(vt.typeChecks[forKind], tagName)
 }
 
 func (vt Valtruc) addCompilation(t reflect.Type, field string, value compiledValidation) {
@@ -185,67 +350,152 @@ func (vt Valtruc) addCompilation(t reflect.Type, field string, value compiledVal
 func (vt Valtruc) Validate(target interface{}) []error {
 	t := reflect.TypeOf(target)
 	v := reflect.ValueOf(target)
+	return vt.validate(t, v, vt.compiledFor(t), vt.newRun())
+}
 
+func (vt Valtruc) compiledFor(t reflect.Type) map[string]compiledValidation {
 	cc, ok := vt.compiled[t]
 	if !ok {
 		vt.compileStructValidation(t)
 		cc = vt.compiled[t]
 	}
+	return cc
+}
+
+func (vt Valtruc) validate(t reflect.Type, v reflect.Value, cc map[string]compiledValidation, run *validationRun) []error {
+	vt.runValidations(t, v, cc, []string{}, run)
+	return vt.result(run.errs)
+}
+
+func (vt Valtruc) newRun() *validationRun {
+	run := &validationRun{
+		ctx:       context.Background(),
+		errs:      []error{},
+		maxErrors: vt.maxErrors,
+		failures:  &atomic.Int64{},
+	}
+	if vt.parallelism > 1 {
+		run.sem = make(chan struct{}, vt.parallelism)
+	}
+	return run
+}
 
-	errs := vt.runValidations(t, v, cc, []string{})
+func (vt Valtruc) result(errs []error) []error {
 	if len(errs) == 0 {
 		return nil
 	}
+	if vt.translator != nil {
+		for i, err := range errs {
+			if verr, ok := err.(ValidationError); ok {
+				verr.msg = vt.translator(verr)
+				errs[i] = verr
+			}
+		}
+	}
 	return errs
 }
 
-func (vt Valtruc) runValidations(t reflect.Type, v reflect.Value, cc map[string]compiledValidation, path []string) []error {
-	resultErrors := []error{}
+func (vt Valtruc) runValidations(t reflect.Type, v reflect.Value, cc map[string]compiledValidation, path []string, run *validationRun) {
 	numFields := t.NumField()
+	if run.sem != nil {
+		run.parallel(numFields, func(i int) bool {
+			return cc[t.Field(i).Name].concurrent || vt.hasSubtree(t.Field(i).Type)
+		}, func(i int, run *validationRun) {
+			vt.runField(t, v, cc, path, i, run)
+		})
+		return
+	}
 	for i := range numFields {
-		fieldType := t.Field(i)
-		fieldValue := v.Field(i)
-
-		ctx := ValidationContext{
-			StructType: t,
-			Field:      fieldType,
-			FieldValue: fieldValue,
-			FieldIndex: i,
-			Path:       path,
+		if run.done() {
+			return
 		}
+		vt.runField(t, v, cc, path, i, run)
+	}
+}
 
-		validator := cc[fieldType.Name]
-		validationResult, errors := validator.validate(ctx)
-		if !validationResult {
-			resultErrors = append(resultErrors, errors...)
-		}
+// hasSubtree tells if values of t have fields to validate. Types with
+// their own validators, like time.Time, are validated as a whole.
+func (vt Valtruc) hasSubtree(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct:
+		_, isValue := vt.typeValidators[t]
+		_, isOptional := vt.unwrapperFor(t)
+		return !isValue && !isOptional && !vt.validatesAsText(t)
+	case reflect.Array, reflect.Slice:
+		return t.Elem().Kind() == reflect.Struct && vt.hasSubtree(t.Elem())
+	}
+	return false
+}
 
-		if fieldType.Type.Kind() == reflect.Struct {
-			subpath := append(path, fieldType.Name)
-			validationErrors := vt.runValidations(fieldType.Type, fieldValue, vt.compiled[fieldType.Type], subpath)
-			resultErrors = append(resultErrors, validationErrors...)
-		}
-		if fieldType.Type.Kind() == reflect.Array || fieldType.Type.Kind() == reflect.Slice {
-			v := fieldValue
-			for j := 0; j < v.Len(); j++ {
-				indexed := v.Index(j)
-				if indexed.Type().Kind() == reflect.Struct {
-					subpath := append(path, fmt.Sprintf("%s[%d]", fieldType.Name, j))
-					validationErrors := vt.runValidations(indexed.Type(), indexed, vt.compiled[indexed.Type()], subpath)
-					resultErrors = append(resultErrors, validationErrors...)
-				}
-			}
+func (vt Valtruc) runField(t reflect.Type, v reflect.Value, cc map[string]compiledValidation, path []string, i int, run *validationRun) {
+	fieldType := t.Field(i)
+	fieldValue := v.Field(i)
+	fieldName := vt.resolveFieldName(fieldType)
+
+	ctx := ValidationContext{
+		Context:     run.ctx,
+		StructType:  t,
+		StructValue: v,
+		Field:       fieldType,
+		FieldName:   fieldName,
+		FieldValue:  fieldValue,
+		FieldIndex:  i,
+		Path:        path,
+	}
+
+	runRules, recurse := run.selects(path, fieldName)
+	validator := cc[fieldType.Name]
+	if validator.skips(fieldValue) {
+		return
+	}
+	if runRules && !validator.validate(ctx, run) && validator.bail {
+		return
+	}
+	if !recurse || !vt.hasSubtree(fieldType.Type) {
+		return
+	}
+
+	if fieldType.Type.Kind() == reflect.Struct {
+		subpath := append(path[:len(path):len(path)], fieldName)
+		vt.runValidations(fieldType.Type, fieldValue, vt.compiled[fieldType.Type], subpath, run)
+		return
+	}
+	vt.runElements(fieldValue, fieldName, path, run)
+}
+
+func (vt Valtruc) runElements(v reflect.Value, fieldName string, path []string, run *validationRun) {
+	elemType := v.Type().Elem()
+	cc := vt.compiled[elemType]
+	runElement := func(j int, run *validationRun) {
+		indexedName := fmt.Sprintf("%s[%d]", fieldName, j)
+		if _, recurse := run.selects(path, indexedName); !recurse {
+			return
 		}
+		subpath := append(path[:len(path):len(path)], indexedName)
+		vt.runValidations(elemType, v.Index(j), cc, subpath, run)
+	}
+
+	if run.sem != nil {
+		run.parallel(v.Len(), func(int) bool { return true }, runElement)
+		return
+	}
+	for j := 0; j < v.Len() && !run.done(); j++ {
+		runElement(j, run)
 	}
-	return resultErrors
 }
 
-type valTag struct {
-	structType reflect.Type
-	field      reflect.StructField
-	original   string
-	name       string
-	parameter  string
+func (vt Valtruc) lookupRules(t reflect.Type, field reflect.StructField) (string, bool) {
+	if fields, ok := vt.rules[t]; ok {
+		if rules, ok := fields[field.Name]; ok {
+			return rules, true
+		}
+	}
+	for _, name := range vt.tagNames {
+		if rules, ok := field.Tag.Lookup(name); ok {
+			return rules, true
+		}
+	}
+	return "", false
 }
 
 func (vt Valtruc) compileStructValidation(t reflect.Type) {
@@ -256,82 +506,132 @@ func (vt Valtruc) compileStructValidation(t reflect.Type) {
 	for i := range numFields {
 		fieldType := t.Field(i)
 
-		if fieldType.Type.Kind() == reflect.Struct {
-			vt.compileStructValidation(fieldType.Type)
-		}
-		if fieldType.Type.Kind() == reflect.Array || fieldType.Type.Kind() == reflect.Slice {
-			underlyingType := fieldType.Type.Elem()
-			if underlyingType.Kind() == reflect.Struct {
-				vt.compileStructValidation(underlyingType)
+		if vt.hasSubtree(fieldType.Type) {
+			if fieldType.Type.Kind() == reflect.Struct {
+				vt.compileStructValidation(fieldType.Type)
+			} else {
+				vt.compileStructValidation(fieldType.Type.Elem())
 			}
 		}
 
-		tag := fieldType.Tag
-		val, ok := tag.Lookup("valtruc")
+		val, ok := vt.lookupRules(t, fieldType)
 		if !ok {
 			continue
 		}
 
-		tags := parseValtrucTag(val, fieldType, t)
+		tags := withFieldGroups(parseValtrucTag(val, fieldType, t), fieldType)
 		cc := vt.compile(tags, fieldType)
 		vt.addCompilation(t, fieldType.Name, cc)
 	}
 }
 
-func parseValtrucTag(tag string, field reflect.StructField, structType reflect.Type) []valTag {
-	tags := strings.Split(tag, ",")
-	result := make([]valTag, len(tags))
-	for i := range len(tags) {
-		t := strings.TrimSpace(tags[i])
+func (vt Valtruc) compile(tags []valTag, field reflect.StructField) compiledValidation {
+	result := compiledValidation{}
 
-		var name, param string
-		startParamsIndex := strings.IndexRune(t, '=')
-		if startParamsIndex != -1 {
-			name = t[0:startParamsIndex]
+	valueType := field.Type
+	isPtr := false
 
-			rest := t[startParamsIndex:]
-			rest = strings.ReplaceAll(rest, "=", "")
-			param = rest
-		} else {
-			name = t
+	if valueType.Kind() == reflect.Ptr {
+		valueType = valueType.Elem()
+		isPtr = true
+	}
+	optional, isOptional := vt.unwrapperFor(valueType)
+	if isOptional {
+		valueType = optional.inner
+	}
+
+	textual := vt.validatesAsText(valueType)
+
+	for _, tag := range vt.expandAliases(tags, []string{}) {
+		if len(tag.alternatives) == 0 && !tag.negated {
+			switch tag.name {
+			case "omitempty":
+				result.omitEmpty = true
+				continue
+			case "bail":
+				result.bail = true
+				continue
+			case "omitnil":
+				if !isPtr {
+					panic(fmt.Sprintf("valtruc: omitnil can only be used on pointers, field %s is %s", field.Name, field.Type))
+				}
+				result.omitNil = true
+				continue
+			}
 		}
 
-		result[i].structType = structType
-		result[i].field = field
-		result[i].original = t
-		result[i].name = name
-		result[i].parameter = param
+		ruleType := valueType
+		asText := textual && !vt.checksValue(valueType, tag)
+		if asText {
+			ruleType = stringType
+		}
+		validator := vt.buildValidator(tag, ruleType)
+		if validator == nil {
+			continue
+		}
+		if asText {
+			validator = textValidatorWrapper(validator)
+		}
+		if vt.usesContext(tag, ruleType.Kind()) {
+			result.concurrent = true
+		}
+		if len(tag.alias) > 0 {
+			validator = aliasValidatorWrapper(validator, tag.alias)
+		}
+		if isOptional {
+			validator = optionalValidatorWrapper(validator, tag, "the field is not valid and is required", optional.unwrap)
+		}
+		if isPtr {
+			validator = ptrValidatorWrapper(validator, tag)
+		}
+		result.add(validator, tag.groups)
 	}
 
 	return result
 }
 
-func (vt Valtruc) compile(tags []valTag, field reflect.StructField) compiledValidation {
-	result := compiledValidation{}
-
-	kind := field.Type.Kind()
-	isPtr := false
-
-	if kind == reflect.Ptr {
-		kind = field.Type.Elem().Kind()
-		isPtr = true
+func (vt Valtruc) buildValidator(tag valTag, t reflect.Type) Validator {
+	if len(tag.alternatives) > 0 {
+		alternatives := make([]Validator, 0, len(tag.alternatives))
+		for _, alternative := range tag.alternatives {
+			if validator := vt.buildValidator(alternative, t); validator != nil {
+				alternatives = append(alternatives, validator)
+			}
+		}
+		if len(alternatives) == 0 {
+			return nil
+		}
+		return anyOf(alternatives, tag)
 	}
 
-	for _, tag := range tags {
+	constructor, ok := vt.typeValidators[t][tag.name]
+	if !ok {
+		kind := t.Kind()
 		validatorsForKind, ok := vt.validators[kind]
 		if !ok {
+			if !vt.strict {
+				return nil
+			}
 			panic(fmt.Sprintf("valtruc: there is no validators for kind %s ", kind))
 		}
-		constructor, ok := validatorsForKind[tag.name]
+		constructor, ok = validatorsForKind[tag.name]
 		if !ok {
+			if !vt.strict {
+				return nil
+			}
 			panic(fmt.Sprintf("valtruc: validator with name %s not found for kind %s", tag.name, kind))
 		}
-		validator := constructor(tag.parameter)
-		if isPtr {
-			validator = ptrValidatorWrapper(validator, tag)
+		if accepts, ok := vt.typeChecks[kind][tag.name]; ok && !accepts(t) {
+			if !vt.strict {
+				return nil
+			}
+			panic(fmt.Sprintf("valtruc: validator with name %s cannot be used with %s", tag.name, t))
 		}
-		result.validators = append(result.validators, validator)
 	}
-
-	return result
+	checkOtherField(tag)
+	validator := constructor(tag.params)
+	if tag.negated {
+		validator = not(validator, tag)
+	}
+	return validator
 }
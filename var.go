@@ -0,0 +1,65 @@
+package valtruc
+
+import "reflect"
+
+const (
+	varFieldName      = "Value"
+	varOtherFieldName = "Other"
+)
+
+type varKey struct {
+	structType reflect.Type
+	tag        string
+}
+
+// Var validates a single value using the same tag language used in structs.
+func (vt Valtruc) Var(value interface{}, tag string) []error {
+	return vt.validateVar(tag, value)
+}
+
+// VarWithValue validates value against other. Field validators like eqfield
+// compare against other when they are used without a parameter.
+func (vt Valtruc) VarWithValue(value interface{}, other interface{}, tag string) []error {
+	return vt.validateVar(tag, value, other)
+}
+
+func (vt Valtruc) validateVar(tag string, values ...interface{}) []error {
+	names := []string{varFieldName, varOtherFieldName}
+	fields := make([]reflect.StructField, len(values))
+	for i, value := range values {
+		if value == nil {
+			panic("valtruc.Var does not accept nil values!")
+		}
+		fields[i] = reflect.StructField{
+			Name: names[i],
+			Type: reflect.TypeOf(value),
+		}
+	}
+
+	t := reflect.StructOf(fields)
+	v := reflect.New(t).Elem()
+	for i, value := range values {
+		v.Field(i).Set(reflect.ValueOf(value))
+	}
+
+	field := t.Field(0)
+	key := varKey{structType: t, tag: tag}
+	cc, ok := vt.vars[key]
+	if !ok {
+		cc = vt.compile(parseValtrucTag(tag, field, t), field)
+		vt.vars[key] = cc
+	}
+
+	run := vt.newRun()
+	ctx := ValidationContext{
+		Context:     run.ctx,
+		StructType:  t,
+		StructValue: v,
+		Field:       field,
+		FieldIndex:  0,
+		FieldValue:  v.Field(0),
+		Path:        []string{},
+	}
+	cc.validate(ctx, run)
+	return vt.result(run.errs)
+}